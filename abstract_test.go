@@ -654,3 +654,160 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+var goTypesPetType = graphql.NewInterface(graphql.InterfaceConfig{
+	Name: "Pet",
+	Fields: graphql.Fields{
+		"name": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var goTypesTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"pets": &graphql.Field{Type: graphql.NewList(goTypesPetType)},
+		},
+	}),
+	Types: []graphql.Type{
+		graphql.NewObject(graphql.ObjectConfig{
+			Name:       "Cat",
+			Interfaces: []*graphql.Interface{goTypesPetType},
+			GoTypes:    []any{reflect.TypeOf(testCat{})},
+			Fields: graphql.Fields{
+				"name":  &graphql.Field{Type: graphql.String},
+				"meows": &graphql.Field{Type: graphql.Boolean},
+			},
+		}),
+		graphql.NewObject(graphql.ObjectConfig{
+			Name:       "Dog",
+			Interfaces: []*graphql.Interface{goTypesPetType},
+			GoTypes:    []any{&testDog{}},
+			Fields: graphql.Fields{
+				"name":  &graphql.Field{Type: graphql.String},
+				"woofs": &graphql.Field{Type: graphql.Boolean},
+			},
+		}),
+	},
+})
+
+func TestGoTypesUsedToResolveRuntimeTypeForInterface(t *testing.T) {
+	pets := []any{
+		&testDog{Name: "Odie", Woofs: true},
+		&testCat{Name: "Garfield", Meows: false},
+		testCat{Name: "Tom", Meows: true},
+	}
+	query := `{
+      pets {
+        name
+        ... on Dog {
+          woofs
+        }
+        ... on Cat {
+          meows
+        }
+      }
+    }`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"pets": []any{
+				map[string]any{
+					"name":  "Odie",
+					"woofs": true,
+				},
+				map[string]any{
+					"name":  "Garfield",
+					"meows": false,
+				},
+				map[string]any{
+					"name":  "Tom",
+					"meows": true,
+				},
+			},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        goTypesTestSchema,
+		RequestString: query,
+		RootObject:    map[string]any{"pets": pets},
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestGoTypesReportsUnmappedGoType(t *testing.T) {
+	pets := []any{
+		&testDog{Name: "Odie", Woofs: true},
+		&testHuman{Name: "Jon"},
+	}
+	query := `{
+      pets {
+        name
+      }
+    }`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"pets": []any{
+				map[string]any{
+					"name": "Odie",
+				},
+				nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message: `Abstract type Pet must resolve to an Object type at runtime for field Query.pets ` +
+					`with value "&{Jon}", received "<nil>". Either the Pet type should provide a ResolveType ` +
+					`function resolving the value, or a possible type should declare Go type ` +
+					`*graphql_test.testHuman in its GoTypes or accept it with IsTypeOf.`,
+				Locations: []location.SourceLocation{
+					{
+						Line:   2,
+						Column: 7,
+					},
+				},
+				Path: []any{"pets", 1},
+			},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        goTypesTestSchema,
+		RequestString: query,
+		RootObject:    map[string]any{"pets": pets},
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestGoTypesMustNotBeAmbiguous(t *testing.T) {
+	petType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	newPetObject := func(name string) *graphql.Object {
+		return graphql.NewObject(graphql.ObjectConfig{
+			Name:       name,
+			Interfaces: []*graphql.Interface{petType},
+			GoTypes:    []any{testDog{}},
+			Fields: graphql.Fields{
+				"name": &graphql.Field{Type: graphql.String},
+			},
+		})
+	}
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pet": &graphql.Field{Type: petType},
+			},
+		}),
+		Types: []graphql.Type{newPetObject("Dog"), newPetObject("Hound")},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for a Go type declared by two possible types")
+	}
+}
@@ -0,0 +1,207 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/ast"
+	"github.com/machship/graphql/language/kinds"
+	"github.com/machship/graphql/language/visitor"
+)
+
+// AuthDirectiveName is the name of the applied directive declaring the
+// requirements of a field or type, e.g. @auth(requires: ["admin"]), which
+// SchemaConfig.Authorizer checks for fields and types without an Authorize
+// policy.
+const AuthDirectiveName = "auth"
+
+// AuthorizeParams Params for AuthorizeFn()
+type AuthorizeParams struct {
+	// Context is the context of the request.
+	Context context.Context
+
+	// Type is the type whose policy is checked, or the parent type of Field.
+	Type Composite
+
+	// Field is the field whose policy is checked, or nil for type policies.
+	Field *FieldDefinition
+
+	// Args are the argument values of Field.
+	Args map[string]any
+
+	// Source is the parent value of Field, or the value of Type. It is nil
+	// when the policy is checked during validation.
+	Source any
+
+	// Requires are the requirements of the applied @auth directive checked by
+	// SchemaConfig.Authorizer.
+	Requires []string
+}
+
+// AuthorizeFn authorizes access to a field or type, returning an error to
+// deny it, e.g. ErrNotAuthorized.
+type AuthorizeFn func(p AuthorizeParams) error
+
+// AuthorizationFailure is how a schema reports denied access.
+type AuthorizationFailure int
+
+const (
+	// AuthorizationFailureNull resolves denied fields, and fields whose
+	// value is of a denied type, to null with an error.
+	AuthorizationFailureNull AuthorizationFailure = iota
+	// AuthorizationFailureOperation fails the whole operation at validation
+	// when a denied field or type is statically reachable from it, i.e. a
+	// selected field or the object or interface type of its value. Types
+	// only known at runtime, e.g. the members of a union, still resolve to
+	// null with an error.
+	AuthorizationFailureOperation
+)
+
+// ErrNotAuthorized is an error for an AuthorizeFn to deny access with.
+var ErrNotAuthorized = &RequestError{Message: "Not authorized.", Code: "FORBIDDEN"}
+
+// authorizeField checks the policies of a field of parentType, i.e. those of
+// fieldDef and of the same field of the interfaces of parentType.
+func authorizeField(schema *Schema, ctx context.Context, parentType Composite, fieldDef *FieldDefinition, args map[string]any, source any) error {
+	p := AuthorizeParams{
+		Context: ctx,
+		Type:    parentType,
+		Field:   fieldDef,
+		Args:    args,
+		Source:  source,
+	}
+	if err := checkPolicy(schema, fieldDef.Authorize, fieldDef.Directives, p); err != nil {
+		return err
+	}
+	object, ok := parentType.(*Object)
+	if !ok {
+		return nil
+	}
+	for _, iface := range object.Interfaces() {
+		ifaceField, ok := iface.Fields()[fieldDef.Name]
+		if !ok {
+			continue
+		}
+		p.Field = ifaceField
+		if err := checkPolicy(schema, ifaceField.Authorize, ifaceField.Directives, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizeType checks the policy of ttype and, for objects, of their
+// interfaces.
+func authorizeType(schema *Schema, ctx context.Context, ttype Composite, source any) error {
+	p := AuthorizeParams{
+		Context: ctx,
+		Type:    ttype,
+		Source:  source,
+	}
+	switch ttype := ttype.(type) {
+	case *Object:
+		if err := checkPolicy(schema, ttype.Authorize, ttype.directives, p); err != nil {
+			return err
+		}
+		for _, iface := range ttype.Interfaces() {
+			if err := checkPolicy(schema, iface.Authorize, iface.directives, p); err != nil {
+				return err
+			}
+		}
+	case *Interface:
+		return checkPolicy(schema, ttype.Authorize, ttype.directives, p)
+	}
+	return nil
+}
+
+// checkPolicy checks policy, or else the applied @auth directives among
+// directives with the authorizer of the schema. Directives are denied if the
+// schema has no authorizer.
+func checkPolicy(schema *Schema, policy AuthorizeFn, directives []*AppliedDirective, p AuthorizeParams) error {
+	if p.Context == nil {
+		p.Context = context.Background()
+	}
+	if policy != nil {
+		return policy(p)
+	}
+	for _, directive := range directives {
+		if directive == nil || directive.Name != AuthDirectiveName {
+			continue
+		}
+		if schema.authorizer == nil {
+			return ErrNotAuthorized
+		}
+		p.Requires = authRequirements(directive)
+		if err := schema.authorizer(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authRequirements returns the values of the requires argument of an
+// applied @auth directive.
+func authRequirements(directive *AppliedDirective) []string {
+	for _, arg := range directive.Args {
+		if arg.Name != "requires" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case string:
+			return []string{value}
+		case []string:
+			return value
+		case []any:
+			requires := []string{}
+			for _, v := range value {
+				if v, ok := v.(string); ok {
+					requires = append(requires, v)
+				}
+			}
+			return requires
+		}
+	}
+	return nil
+}
+
+// AuthorizationRule returns a validation rule rejecting the operations from
+// which a field or type denied to the request with context ctx is statically
+// reachable. Do and Subscribe run it for schemas with
+// AuthorizationFailureOperation; the executor checks the policies as well,
+// for the types only known at runtime and for requests executed without
+// validation.
+func AuthorizationRule(ctx context.Context) ValidationRuleFn {
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		visitorOpts := &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.Field: {
+					Kind: func(p visitor.VisitFuncParams) (string, any) {
+						field, ok := p.Node.(*ast.Field)
+						if !ok {
+							return visitor.ActionNoChange, nil
+						}
+						fieldDef := context.FieldDef()
+						if fieldDef == nil {
+							return visitor.ActionNoChange, nil
+						}
+						schema := context.Schema()
+						args := getArgumentValues(fieldDef.Args, field.Arguments, context.VariableValues())
+						err := authorizeField(schema, ctx, context.ParentType(), fieldDef, args, nil)
+						if err == nil {
+							if ttype, ok := GetNamed(fieldDef.Type).(Composite); ok {
+								err = authorizeType(schema, ctx, ttype, nil)
+							}
+						}
+						if err != nil {
+							context.ReportError(gqlerrors.NewError(err.Error(), []ast.Node{field}, "", nil, []int{}, err))
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+			},
+		}
+		return &ValidationRuleInstance{
+			VisitorOpts: visitorOpts,
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package graphql_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/location"
+	"github.com/machship/graphql/testutil"
+)
+
+type roleKey struct{}
+
+func roleOf(ctx context.Context) string {
+	role, _ := ctx.Value(roleKey{}).(string)
+	return role
+}
+
+type authPost struct{ Title string }
+
+type authNote struct{ Text string }
+
+var authPostType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Post",
+	Fields: graphql.Fields{
+		"title": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var authNoteType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Note",
+	Fields: graphql.Fields{
+		"text": &graphql.Field{Type: graphql.String},
+	},
+	Directives: []*graphql.AppliedDirective{{
+		Name: graphql.AuthDirectiveName,
+		Args: []*graphql.DirectiveArgument{{Name: "requires", Value: []any{"admin"}}},
+	}},
+})
+
+var authorizationTestQuery = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Query",
+	Fields: graphql.Fields{
+		"hello": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return "world", nil
+			},
+		},
+		"secret": &graphql.Field{
+			Type: graphql.String,
+			Authorize: func(p graphql.AuthorizeParams) error {
+				if roleOf(p.Context) == "" {
+					return graphql.ErrNotAuthorized
+				}
+				return nil
+			},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return "s3cret", nil
+			},
+		},
+		"note": &graphql.Field{
+			Type: authNoteType,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return authNote{Text: "note"}, nil
+			},
+		},
+		"search": &graphql.Field{
+			Type: graphql.NewList(graphql.NewUnion(graphql.UnionConfig{
+				Name:  "SearchResult",
+				Types: []*graphql.Object{authPostType, authNoteType},
+				ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+					if _, ok := p.Value.(authNote); ok {
+						return authNoteType
+					}
+					return authPostType
+				},
+			})),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return []any{authPost{Title: "post"}, authNote{Text: "note"}}, nil
+			},
+		},
+	},
+})
+
+func authorizeRole(p graphql.AuthorizeParams) error {
+	if !slices.Contains(p.Requires, roleOf(p.Context)) {
+		return graphql.ErrNotAuthorized
+	}
+	return nil
+}
+
+var authorizationTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query:                authorizationTestQuery,
+	Authorizer:           authorizeRole,
+	AuthorizationFailure: graphql.AuthorizationFailureNull,
+})
+
+var authorizationOperationTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query:                authorizationTestQuery,
+	Authorizer:           authorizeRole,
+	AuthorizationFailure: graphql.AuthorizationFailureOperation,
+})
+
+func notAuthorizedError(line, column int, path ...any) gqlerrors.FormattedError {
+	return gqlerrors.FormattedError{
+		Message:    graphql.ErrNotAuthorized.Message,
+		Locations:  []location.SourceLocation{{Line: line, Column: column}},
+		Path:       path,
+		Extensions: map[string]any{"code": "FORBIDDEN"},
+	}
+}
+
+func TestAuthorization_DeniedFieldsResolveToNull(t *testing.T) {
+	schema := authorizationTestSchema
+	query := `{ hello secret }`
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	expected := &graphql.Result{
+		Data:   map[string]any{"hello": "world", "secret": nil},
+		Errors: []gqlerrors.FormattedError{notAuthorizedError(1, 9, "secret")},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		Context:       context.WithValue(context.Background(), roleKey{}, "user"),
+	})
+	expected = &graphql.Result{
+		Data: map[string]any{"hello": "world", "secret": "s3cret"},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestAuthorization_ProtectsTypesReachedThroughUnions(t *testing.T) {
+	schema := authorizationTestSchema
+	query := `{ search { ... on Post { title } ... on Note { text } } }`
+	tests := []struct {
+		role     string
+		expected *graphql.Result
+	}{
+		{
+			role: "user",
+			expected: &graphql.Result{
+				Data: map[string]any{
+					"search": []any{map[string]any{"title": "post"}, nil},
+				},
+				Errors: []gqlerrors.FormattedError{notAuthorizedError(1, 3, "search", 1)},
+			},
+		},
+		{
+			role: "admin",
+			expected: &graphql.Result{
+				Data: map[string]any{
+					"search": []any{map[string]any{"title": "post"}, map[string]any{"text": "note"}},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+			Context:       context.WithValue(context.Background(), roleKey{}, test.role),
+		})
+		if !testutil.EqualResults(test.expected, result) {
+			t.Fatalf("role %q: unexpected result, Diff: %v", test.role, testutil.Diff(test.expected, result))
+		}
+	}
+}
+
+func TestAuthorization_FailsOperationsReachingDeniedFields(t *testing.T) {
+	schema := authorizationOperationTestSchema
+	ctx := context.WithValue(context.Background(), roleKey{}, "user")
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hello note { text } }`,
+		Context:       ctx,
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{{
+			Message:    graphql.ErrNotAuthorized.Message,
+			Locations:  []location.SourceLocation{{Line: 1, Column: 9}},
+			Extensions: map[string]any{"code": "FORBIDDEN"},
+		}},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// the members of a union are only known at runtime
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ secret search { ... on Note { text } } }`,
+		Context:       ctx,
+	})
+	expected = &graphql.Result{
+		Data: map[string]any{
+			"secret": "s3cret",
+			"search": []any{map[string]any{}, nil},
+		},
+		Errors: []gqlerrors.FormattedError{notAuthorizedError(1, 10, "search", 1)},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestAuthorization_TypeDenialsHaveNoStackTrace(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        authorizationTestSchema,
+		RequestString: `{ note { text } }`,
+		Context:       context.WithValue(context.Background(), roleKey{}, "user"),
+		Debug:         true,
+	})
+	expected := &graphql.Result{
+		Data:   map[string]any{"note": nil},
+		Errors: []gqlerrors.FormattedError{notAuthorizedError(1, 3, "note")},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if _, ok := result.Errors[0].Extensions["stacktrace"]; ok {
+		t.Fatalf("expected no stack trace, got %v", result.Errors[0].Extensions)
+	}
+}
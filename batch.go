@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchOption configures DoBatch.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	parallelism int
+}
+
+// BatchParallelism executes up to n operations of a batch at once. By
+// default, the operations are executed one after the other.
+func BatchParallelism(n int) BatchOption {
+	return func(o *batchOptions) {
+		o.parallelism = n
+	}
+}
+
+// DoBatch executes a batch of operations against schema, e.g. the operations
+// of a batched HTTP request, and returns their results in the same order.
+//
+// The operations share the context of the batch, which is the Context of the
+// first operation, so that request-scoped caches such as data loaders are
+// shared. The Init of the extensions of schema runs once for the batch, while
+// the other extension hooks and results are per operation. The Schema and
+// Context of each Params are ignored.
+func DoBatch(schema Schema, params []Params, options ...BatchOption) []*Result {
+	o := batchOptions{}
+	for _, option := range options {
+		option(&o)
+	}
+	results := make([]*Result, len(params))
+	if len(params) == 0 {
+		return results
+	}
+
+	batch := params[0]
+	batch.Schema = schema
+	if batch.Context == nil {
+		batch.Context = context.Background()
+	}
+	if extErrs := handleExtensionsInits(&batch); len(extErrs) != 0 {
+		for i := range results {
+			results[i] = &Result{
+				Errors: extErrs,
+			}
+		}
+		return results
+	}
+
+	execute := func(i int) {
+		p := params[i]
+		p.Schema = schema
+		p.Context = batch.Context
+		results[i] = do(p, false)
+	}
+	if o.parallelism <= 1 {
+		for i := range params {
+			execute(i)
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, o.parallelism)
+	for i := range params {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			execute(i)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
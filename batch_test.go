@@ -0,0 +1,129 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/testutil"
+)
+
+type batchLoaderKey struct{}
+
+// batchLoader stands for a request-scoped cache shared by the operations of
+// a batch.
+type batchLoader struct {
+	loads atomic.Int32
+}
+
+// batchLoaderExt provides each batch with a batchLoader, counting the calls
+// of its Init in inits.
+func batchLoaderExt(inits *int) *testExt {
+	ext := newtestExt("loader")
+	ext.initFn = func(ctx context.Context, p *graphql.Params) context.Context {
+		*inits++
+		return context.WithValue(ctx, batchLoaderKey{}, &batchLoader{})
+	}
+	ext.hasResultFn = func() bool {
+		return true
+	}
+	ext.getResultFn = func(ctx context.Context) any {
+		return ctx.Value(batchLoaderKey{}) != nil
+	}
+	return ext
+}
+
+func batchParams(values ...string) []graphql.Params {
+	params := []graphql.Params{}
+	for _, value := range values {
+		params = append(params, graphql.Params{
+			RequestString:  `query ($value: String) { test(value: $value) }`,
+			VariableValues: map[string]any{"value": value},
+		})
+	}
+	return params
+}
+
+func TestDoBatch_SharesContextAndRunsInitOnce(t *testing.T) {
+	inits := 0
+	loaders := map[*batchLoader]bool{}
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"value": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			loader := p.Context.Value(batchLoaderKey{}).(*batchLoader)
+			loader.loads.Add(1)
+			loaders[loader] = true
+			return p.Args["value"], nil
+		},
+	})
+	schema.AddExtensions(batchLoaderExt(&inits))
+	params := batchParams("a", "b", "c")
+	params = append(params, graphql.Params{RequestString: `{ unknown }`})
+
+	results := graphql.DoBatch(schema, params)
+	if inits != 1 {
+		t.Fatalf("expected Init to run once, ran %v times", inits)
+	}
+	if len(loaders) != 1 {
+		t.Fatalf("expected the operations to share one loader, got %v", len(loaders))
+	}
+	for loader := range loaders {
+		if loads := loader.loads.Load(); loads != 3 {
+			t.Fatalf("expected 3 loads, got %v", loads)
+		}
+	}
+	for i, value := range []string{"a", "b", "c"} {
+		expected := &graphql.Result{
+			Data:       map[string]any{"test": value},
+			Extensions: map[string]any{"loader": true},
+		}
+		if !testutil.EqualResults(expected, results[i]) {
+			t.Fatalf("Unexpected result %v, Diff: %v", i, testutil.Diff(expected, results[i]))
+		}
+	}
+	if len(results[3].Errors) != 1 {
+		t.Fatalf("expected a validation error, got %v", results[3])
+	}
+}
+
+func TestDoBatch_ExecutesInParallelPreservingOrder(t *testing.T) {
+	inits := 0
+	var started sync.WaitGroup
+	started.Add(3)
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"value": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			// every operation waits for the others to start
+			started.Done()
+			started.Wait()
+			return p.Args["value"], nil
+		},
+	})
+	schema.AddExtensions(batchLoaderExt(&inits))
+
+	results := graphql.DoBatch(schema, batchParams("a", "b", "c"), graphql.BatchParallelism(3))
+	data := []any{}
+	for _, result := range results {
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		data = append(data, result.Data)
+	}
+	expected := []any{
+		map[string]any{"test": "a"},
+		map[string]any{"test": "b"},
+		map[string]any{"test": "c"},
+	}
+	if !reflect.DeepEqual(expected, data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, data))
+	}
+}
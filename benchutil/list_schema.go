@@ -83,6 +83,55 @@ func ListSchemaWithXItems(x int) graphql.Schema {
 	return colorSchema
 }
 
+// ListSchemaWithXItemsDefaultResolve is like ListSchemaWithXItems, but leaves
+// the color fields without resolvers so that they are resolved from the
+// struct fields by graphql.DefaultResolveFn.
+func ListSchemaWithXItemsDefaultResolve(x int) graphql.Schema {
+
+	list := generateXListItems(x)
+
+	color := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Color",
+		Description: "A color",
+		Fields: graphql.Fields{
+			"hex": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Hex color code.",
+			},
+			"r": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Red value.",
+			},
+			"g": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Green value.",
+			},
+			"b": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Blue value.",
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"colors": {
+				Type: graphql.NewList(color),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return list, nil
+				},
+			},
+		},
+	})
+
+	colorSchema, _ := graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+
+	return colorSchema
+}
+
 var colors []color
 
 func init() {
@@ -0,0 +1,281 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/machship/graphql/language/ast"
+	"github.com/machship/graphql/language/kinds"
+	"github.com/machship/graphql/language/visitor"
+)
+
+// CostDirectiveName is the name of the applied directive declaring the cost
+// of a field, e.g. @cost(weight: 5, multipliers: ["first"]), used by
+// CostRule for fields without a FieldCost.
+const CostDirectiveName = "cost"
+
+// FieldCost is the cost of a field for CostRule.
+type FieldCost struct {
+	// Weight is the cost of resolving the field once.
+	Weight int
+	// Multipliers are the names of the arguments whose values multiply the
+	// cost of the field and its selections, e.g. "first" for a paginated
+	// list. If empty, the CostConfig.ListSizeArguments of list fields are
+	// used.
+	Multipliers []string
+}
+
+// CostConfig configures a CostRule.
+type CostConfig struct {
+	// MaxCost is the maximum cost of an operation, or 0 for no maximum.
+	MaxCost int
+	// DefaultCost is the weight of fields without a FieldCost or an applied
+	// @cost directive. If zero, such fields weigh 1.
+	DefaultCost int
+	// ListSizeArguments are the names of the arguments whose values multiply
+	// the cost of list fields without multipliers. If nil, "first" and
+	// "limit" are used.
+	ListSizeArguments []string
+	// ExtensionKey, if set, reports the cost of the operation and MaxCost in
+	// the extensions of the result under this key. For documents with several
+	// operations, the highest cost is reported.
+	ExtensionKey string
+}
+
+// CostReport is the value reported in the extensions of the result under
+// CostConfig.ExtensionKey.
+type CostReport struct {
+	Cost    int `json:"cost"`
+	MaxCost int `json:"maxCost,omitempty"`
+}
+
+// CostRule returns a validation rule computing the cost of each operation
+// and rejecting operations costing more than config.MaxCost. It is meant for
+// Params.ValidationRules, where the values of variables used as multipliers
+// are known.
+//
+// The cost of a field is (weight + the cost of its selections) * multiplier,
+// where multiplier is the product of the values of its multiplier arguments
+// which are set, or 1. Fragments count once per spread, and every inline
+// fragment of an abstract type counts, so that the cost is an upper bound.
+func CostRule(config CostConfig) ValidationRuleFn {
+	if config.DefaultCost == 0 {
+		config.DefaultCost = 1
+	}
+	if config.ListSizeArguments == nil {
+		config.ListSizeArguments = []string{"first", "limit"}
+	}
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		reported := -1
+		visitorOpts := &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.OperationDefinition: {
+					Kind: func(p visitor.VisitFuncParams) (string, any) {
+						operation, ok := p.Node.(*ast.OperationDefinition)
+						if !ok {
+							return visitor.ActionSkip, nil
+						}
+						cost := newCostAnalysis(context, config, operation).operationCost()
+						if config.ExtensionKey != "" && cost > reported {
+							reported = cost
+							context.SetExtension(config.ExtensionKey, CostReport{Cost: cost, MaxCost: config.MaxCost})
+						}
+						if config.MaxCost > 0 && cost > config.MaxCost {
+							reportError(
+								context,
+								CostExceededMessage(operation, cost, config.MaxCost),
+								[]ast.Node{operation},
+							)
+						}
+						return visitor.ActionSkip, nil
+					},
+				},
+			},
+		}
+		return &ValidationRuleInstance{
+			VisitorOpts: visitorOpts,
+		}
+	}
+}
+
+// CostExceededMessage is the message of the error reported by CostRule for
+// operations costing more than the maximum cost.
+func CostExceededMessage(operation *ast.OperationDefinition, cost int, maxCost int) string {
+	return fmt.Sprintf(`%v has a cost of %v, which exceeds the maximum cost of %v.`, operationSubject(operation), cost, maxCost)
+}
+
+// costAnalysis computes the cost of an operation.
+type costAnalysis struct {
+	context   *ValidationContext
+	config    CostConfig
+	operation *ast.OperationDefinition
+	variables map[string]any
+
+	selections *selectionFold[int]
+}
+
+func newCostAnalysis(context *ValidationContext, config CostConfig, operation *ast.OperationDefinition) *costAnalysis {
+	variables, err := getVariableValues(*context.Schema(), operation.VariableDefinitions, context.VariableValues())
+	if err != nil {
+		// invalid variables are reported when executing
+		variables = context.VariableValues()
+	}
+	ca := &costAnalysis{
+		context:   context,
+		config:    config,
+		operation: operation,
+		variables: variables,
+	}
+	ca.selections = newSelectionFold(context, ca.fieldCost, addCost)
+	return ca
+}
+
+func (ca *costAnalysis) operationCost() int {
+	schema := ca.context.Schema()
+	var rootType *Object
+	switch ca.operation.Operation {
+	case ast.OperationTypeQuery:
+		rootType = schema.QueryType()
+	case ast.OperationTypeMutation:
+		rootType = schema.MutationType()
+	case ast.OperationTypeSubscription:
+		rootType = schema.SubscriptionType()
+	}
+	if rootType == nil {
+		return 0
+	}
+	return ca.selections.selectionSet(rootType, ca.operation.SelectionSet)
+}
+
+func (ca *costAnalysis) fieldCost(parentType Type, field *ast.Field) int {
+	fieldDef := DefaultTypeInfoFieldDef(ca.context.Schema(), parentType, field)
+	if fieldDef == nil {
+		return 0
+	}
+	weight, multipliers := ca.config.DefaultCost, []string(nil)
+	if fieldCost := fieldCostOf(fieldDef); fieldCost != nil {
+		weight, multipliers = fieldCost.Weight, fieldCost.Multipliers
+	}
+	if len(multipliers) == 0 && isListType(fieldDef.Type) {
+		multipliers = ca.config.ListSizeArguments
+	}
+	fieldType, _ := GetNamed(fieldDef.Type).(Type)
+	cost := addCost(max(weight, 0), ca.selections.selectionSet(fieldType, field.SelectionSet))
+	return multiplyCost(cost, ca.multiplier(fieldDef, field, multipliers))
+}
+
+// multiplier returns the product of the values of the given arguments of
+// field which are set, or 1 if none is.
+func (ca *costAnalysis) multiplier(fieldDef *FieldDefinition, field *ast.Field, names []string) int {
+	multiplier := 1
+	for _, name := range names {
+		var argDef *Argument
+		for _, arg := range fieldDef.Args {
+			if arg.Name() == name {
+				argDef = arg
+			}
+		}
+		if argDef == nil {
+			continue
+		}
+		var value any
+		for _, arg := range field.Arguments {
+			if arg.Name != nil && arg.Name.Value == name {
+				value = valueFromAST(arg.Value, Int, ca.variables)
+			}
+		}
+		if value == nil {
+			value = argDef.DefaultValue
+		}
+		if n, ok := costInt(value); ok {
+			multiplier = multiplyCost(multiplier, max(n, 0))
+		}
+	}
+	return multiplier
+}
+
+// fieldCostOf returns the FieldCost of a field, or the one declared by its
+// applied @cost directive.
+func fieldCostOf(fieldDef *FieldDefinition) *FieldCost {
+	if fieldDef.Cost != nil {
+		return fieldDef.Cost
+	}
+	for _, directive := range fieldDef.Directives {
+		if directive == nil || directive.Name != CostDirectiveName {
+			continue
+		}
+		fieldCost := &FieldCost{}
+		for _, arg := range directive.Args {
+			switch arg.Name {
+			case "weight":
+				fieldCost.Weight, _ = costInt(arg.Value)
+			case "multipliers":
+				fieldCost.Multipliers = costMultipliers(arg.Value)
+			}
+		}
+		return fieldCost
+	}
+	return nil
+}
+
+func isListType(ttype Type) bool {
+	if nonNull, ok := ttype.(*NonNull); ok {
+		ttype = nonNull.OfType
+	}
+	_, ok := ttype.(*List)
+	return ok
+}
+
+func costInt(value any) (int, bool) {
+	switch value := value.(type) {
+	case int:
+		return value, true
+	case int32:
+		return int(value), true
+	case int64:
+		return int(value), true
+	case float64:
+		return int(value), true
+	case string:
+		n, err := strconv.Atoi(value)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func costMultipliers(value any) []string {
+	switch value := value.(type) {
+	case []string:
+		return value
+	case []any:
+		names := []string{}
+		for _, name := range value {
+			if name, ok := name.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	case string:
+		return strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '[' || r == ']' || r == '"'
+		})
+	}
+	return nil
+}
+
+// addCost and multiplyCost saturate rather than overflow.
+func addCost(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+func multiplyCost(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
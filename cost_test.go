@@ -0,0 +1,138 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/testutil"
+)
+
+var costUserType *graphql.Object
+var costTestSchema graphql.Schema
+
+func init() {
+	costUserType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: (graphql.FieldsThunk)(func() graphql.Fields {
+			return graphql.Fields{
+				"name": &graphql.Field{Type: graphql.String},
+				"friends": &graphql.Field{
+					Type: graphql.NewList(costUserType),
+					Args: graphql.FieldConfigArgument{
+						"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					},
+				},
+				"avatar": &graphql.Field{
+					Type: graphql.String,
+					Directives: []*graphql.AppliedDirective{{
+						Name: "cost",
+						Args: []*graphql.DirectiveArgument{
+							{Name: "weight", Value: "5"},
+							{Name: "multipliers", Value: []any{"size"}},
+						},
+					}},
+					Args: graphql.FieldConfigArgument{
+						"size": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+				},
+			}
+		}),
+	})
+	costTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type: graphql.NewList(costUserType),
+					Args: graphql.FieldConfigArgument{
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return []any{map[string]any{"name": "Ada"}}, nil
+					},
+				},
+				"search": &graphql.Field{
+					Type: graphql.String,
+					Cost: &graphql.FieldCost{Weight: 50},
+				},
+			},
+		}),
+	})
+}
+
+func TestCostRule_ComputesCost(t *testing.T) {
+	schema := costTestSchema
+	tests := []struct {
+		query     string
+		variables map[string]any
+		cost      int
+	}{
+		// 1
+		{query: `{ users { name } }`, cost: 2},
+		// limit * (1 + 1)
+		{query: `{ users(limit: 5) { name } }`, cost: 10},
+		{query: `query ($n: Int) { users(limit: $n) { name } }`, variables: map[string]any{"n": float64(3)}, cost: 6},
+		{query: `query ($n: Int = 4) { users(limit: $n) { name } }`, cost: 8},
+		// the default of first is 10
+		{query: `{ users(limit: 2) { friends { name } } }`, cost: 2 * (1 + 10*(1+1))},
+		// @cost(weight: 5, multipliers: ["size"])
+		{query: `{ users(limit: 1) { avatar(size: 3) } }`, cost: 1 + 5*3},
+		// FieldCost
+		{query: `{ search }`, cost: 50},
+		// once per spread
+		{query: `{ users(limit: 1) { ...F ...F } } fragment F on User { name }`, cost: 3},
+		{query: `{ a: users(limit: 1) { ...F } b: users(limit: 2) { ...F } } fragment F on User { name }`, cost: 2 + 4},
+		{query: `{ users(limit: 1) { ... on User { name } } }`, cost: 2},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:          schema,
+			RequestString:   test.query,
+			VariableValues:  test.variables,
+			ValidationRules: []graphql.ValidationRuleFn{graphql.CostRule(graphql.CostConfig{ExtensionKey: "cost"})},
+		})
+		if len(result.Errors) > 0 {
+			t.Fatalf("%v: unexpected errors: %v", test.query, result.Errors)
+		}
+		expected := graphql.CostReport{Cost: test.cost}
+		if !reflect.DeepEqual(result.Extensions["cost"], expected) {
+			t.Fatalf("%v: Unexpected cost, Diff: %v", test.query, testutil.Diff(expected, result.Extensions["cost"]))
+		}
+	}
+}
+
+func TestCostRule_RejectsOperationsAboveMaxCost(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:         costTestSchema,
+		RequestString:  `query Users($n: Int) { users(limit: $n) { friends(first: 100) { name } } }`,
+		VariableValues: map[string]any{"n": 50},
+		ValidationRules: []graphql.ValidationRuleFn{graphql.CostRule(graphql.CostConfig{
+			MaxCost:      1000,
+			ExtensionKey: "cost",
+		})},
+	})
+	if result.Data != nil {
+		t.Fatalf("Unexpected data: %v", result.Data)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expectedMessage := `Operation "Users" has a cost of 10050, which exceeds the maximum cost of 1000.`
+	if result.Errors[0].Message != expectedMessage {
+		t.Fatalf("Unexpected message: %v", result.Errors[0].Message)
+	}
+	expected := graphql.CostReport{Cost: 10050, MaxCost: 1000}
+	if !reflect.DeepEqual(result.Extensions["cost"], expected) {
+		t.Fatalf("Unexpected cost, Diff: %v", testutil.Diff(expected, result.Extensions["cost"]))
+	}
+}
+
+func TestCostRule_SurvivesFragmentCycles(t *testing.T) {
+	schema := costTestSchema
+	AST := testutil.TestParse(t, `{ users { ...A } } fragment A on User { friends { ...B } } fragment B on User { friends { ...A } }`)
+	result := graphql.ValidateDocument(&schema, AST, []graphql.ValidationRuleFn{graphql.CostRule(graphql.CostConfig{MaxCost: 1})})
+	if result.IsValid {
+		t.Fatal("Expected the operation to exceed the maximum cost")
+	}
+}
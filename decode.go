@@ -0,0 +1,391 @@
+package graphql
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DecodeArgs decodes the coerced arguments of the field being resolved into
+// dst, which must be a non-nil pointer to a struct or a map with string keys.
+//
+// Arguments and input object fields are matched to struct fields by their
+// `graphql` tag, then their `json` tag, then case-insensitively by field name.
+// Fields of embedded structs are promoted as with encoding/json. Lists decode
+// into slices or arrays, enums into any Go type their values convert to, and
+// DateTime into time.Time.
+//
+// An absent argument leaves its destination field untouched and an explicit
+// null sets it to its zero value. A double pointer field (e.g. **string)
+// distinguishes the two: it stays nil when the argument is absent and points
+// to a nil pointer when the argument is null.
+//
+// If a value cannot be decoded, a located error for the current field is
+// returned naming the offending argument path.
+func (p ResolveParams) DecodeArgs(dst any) error {
+	dstVal := reflect.ValueOf(dst)
+	if !dstVal.IsValid() || dstVal.Kind() != reflect.Ptr || dstVal.IsNil() {
+		return p.decodeError(fmt.Errorf("DecodeArgs requires a non-nil pointer, got %T", dst))
+	}
+	dstVal = dstVal.Elem()
+
+	argTypes := map[string]Input{}
+	for _, arg := range fieldArgumentDefs(p.Info) {
+		argTypes[arg.PrivateName] = arg.Type
+	}
+	if err := decodeObject(argTypes, p.Args, dstVal, ""); err != nil {
+		return p.decodeError(err)
+	}
+	return nil
+}
+
+// DecodeArgs decodes the coerced arguments of the field being resolved into a
+// new value of type T. See ResolveParams.DecodeArgs for the decoding rules.
+func DecodeArgs[T any](p ResolveParams) (T, error) {
+	var dst T
+	err := p.DecodeArgs(&dst)
+	return dst, err
+}
+
+func (p ResolveParams) decodeError(err error) error {
+	return NewLocatedErrorWithPath(err, FieldASTsToNodeASTs(p.Info.FieldASTs), p.Info.Path.AsArray())
+}
+
+// fieldArgumentDefs looks up the argument definitions of the field described
+// by info, returning nil if the field cannot be found.
+func fieldArgumentDefs(info ResolveInfo) []*Argument {
+	switch parentType := info.ParentType.(type) {
+	case *Object:
+		if fieldDef := getFieldDef(info.Schema, parentType, info.FieldName); fieldDef != nil {
+			return fieldDef.Args
+		}
+	case *Interface:
+		if fieldDef := parentType.Fields()[info.FieldName]; fieldDef != nil {
+			return fieldDef.Args
+		}
+	}
+	return nil
+}
+
+// decodeError describes a value which could not be decoded into its
+// destination.
+type decodeError struct {
+	path    string
+	message string
+}
+
+func (e *decodeError) Error() string {
+	if e.path == "" {
+		return fmt.Sprintf("Could not decode arguments: %s.", e.message)
+	}
+	return fmt.Sprintf(`Could not decode argument "%s": %s.`, e.path, e.message)
+}
+
+func newDecodeError(path string, format string, a ...any) error {
+	return &decodeError{path: path, message: fmt.Sprintf(format, a...)}
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decodeValue decodes a coerced input value of type ttype into dst. ttype may
+// be nil when no type information is available, in which case the shape of
+// value alone drives the decoding.
+func decodeValue(ttype Type, value any, dst reflect.Value, path string) error {
+	if nonNull, ok := ttype.(*NonNull); ok {
+		ttype = nonNull.OfType
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		if value == nil {
+			// keep an explicit null observable through double pointers
+			if dst.Type().Elem().Kind() == reflect.Ptr {
+				dst.Set(reflect.New(dst.Type().Elem()))
+			} else {
+				dst.Set(reflect.Zero(dst.Type()))
+			}
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeValue(ttype, value, dst.Elem(), path)
+	}
+
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	val := reflect.ValueOf(value)
+	if dst.Kind() == reflect.Interface {
+		if !val.Type().AssignableTo(dst.Type()) {
+			return newDecodeError(path, "cannot assign %T to %v", value, dst.Type())
+		}
+		dst.Set(val)
+		return nil
+	}
+
+	switch ttype := ttype.(type) {
+	case *List:
+		return decodeList(ttype.OfType, val, dst, path)
+	case *InputObject:
+		valueMap, ok := value.(map[string]any)
+		if !ok {
+			return newDecodeError(path, "expected an input object, got %T", value)
+		}
+		fieldTypes := map[string]Input{}
+		for name, field := range ttype.Fields() {
+			fieldTypes[name] = field.Type
+		}
+		return decodeObject(fieldTypes, valueMap, dst, path)
+	}
+
+	if val.Type().AssignableTo(dst.Type()) {
+		dst.Set(val)
+		return nil
+	}
+	if ttype == nil {
+		switch value := value.(type) {
+		case []any:
+			return decodeList(nil, val, dst, path)
+		case map[string]any:
+			return decodeObject(nil, value, dst, path)
+		}
+	}
+	return decodeLeaf(val, dst, path)
+}
+
+// decodeList decodes a coerced list value into a slice or array.
+func decodeList(itemType Type, val reflect.Value, dst reflect.Value, path string) error {
+	if val.Kind() != reflect.Slice {
+		return newDecodeError(path, "expected a list, got %v", val.Type())
+	}
+	switch dst.Kind() {
+	case reflect.Slice:
+		dst.Set(reflect.MakeSlice(dst.Type(), val.Len(), val.Len()))
+	case reflect.Array:
+		if val.Len() > dst.Len() {
+			return newDecodeError(path, "cannot decode %d items into %v", val.Len(), dst.Type())
+		}
+		dst.Set(reflect.Zero(dst.Type()))
+	default:
+		return newDecodeError(path, "cannot decode a list into %v", dst.Type())
+	}
+	for i := 0; i < val.Len(); i++ {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if err := decodeValue(itemType, val.Index(i).Interface(), dst.Index(i), itemPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeObject decodes a map of coerced values into a struct or a map with
+// string keys. fieldTypes maps each known field name to its input type; keys
+// of valueMap without a known type are decoded by shape alone.
+func decodeObject(fieldTypes map[string]Input, valueMap map[string]any, dst reflect.Value, path string) error {
+	// to ensure stable order of field decoding, and therefore of errors
+	names := make([]string, 0, len(valueMap))
+	for name := range valueMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		fields := decodableFields(dst.Type())
+		for _, name := range names {
+			index, ok := lookupDecodableField(fields, name)
+			if !ok {
+				continue
+			}
+			fieldDst, err := fieldByIndexAlloc(dst, index)
+			if err != nil {
+				return newDecodeError(joinArgPath(path, name), "%v", err)
+			}
+			if err := decodeValue(inputType(fieldTypes, name), valueMap[name], fieldDst, joinArgPath(path, name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if dst.Type().Key().Kind() != reflect.String {
+			return newDecodeError(path, "cannot decode an object into %v", dst.Type())
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), len(valueMap)))
+		}
+		elemType := dst.Type().Elem()
+		for _, name := range names {
+			elem := reflect.New(elemType).Elem()
+			if err := decodeValue(inputType(fieldTypes, name), valueMap[name], elem, joinArgPath(path, name)); err != nil {
+				return err
+			}
+			dst.SetMapIndex(reflect.ValueOf(name).Convert(dst.Type().Key()), elem)
+		}
+		return nil
+	}
+	return newDecodeError(path, "cannot decode an object into %v", dst.Type())
+}
+
+// decodeLeaf converts a coerced scalar or enum value into dst.
+func decodeLeaf(val reflect.Value, dst reflect.Value, path string) error {
+	if val.Kind() == reflect.String && reflect.PointerTo(dst.Type()).Implements(textUnmarshalerType) {
+		if err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(val.String())); err != nil {
+			return newDecodeError(path, "%v", err)
+		}
+		return nil
+	}
+
+	switch {
+	case val.Kind() == reflect.String && dst.Kind() == reflect.String,
+		val.Kind() == reflect.Bool && dst.Kind() == reflect.Bool:
+		dst.Set(val.Convert(dst.Type()))
+		return nil
+	case isIntKind(val.Kind()) && isIntKind(dst.Kind()):
+		if dst.OverflowInt(val.Int()) {
+			return newDecodeError(path, "%v overflows %v", val.Int(), dst.Type())
+		}
+		dst.SetInt(val.Int())
+		return nil
+	case isIntKind(val.Kind()) && isUintKind(dst.Kind()):
+		if val.Int() < 0 || dst.OverflowUint(uint64(val.Int())) {
+			return newDecodeError(path, "%v overflows %v", val.Int(), dst.Type())
+		}
+		dst.SetUint(uint64(val.Int()))
+		return nil
+	case isIntKind(val.Kind()) && isFloatKind(dst.Kind()):
+		dst.SetFloat(float64(val.Int()))
+		return nil
+	case isFloatKind(val.Kind()) && isFloatKind(dst.Kind()):
+		if dst.OverflowFloat(val.Float()) {
+			return newDecodeError(path, "%v overflows %v", val.Float(), dst.Type())
+		}
+		dst.SetFloat(val.Float())
+		return nil
+	}
+	return newDecodeError(path, "cannot assign %v to %v", val.Type(), dst.Type())
+}
+
+// decodableFields maps the names a struct's fields may be decoded from to
+// their index paths, including fields promoted from embedded structs.
+// Explicitly tagged names take precedence over plain field names.
+func decodableFields(t reflect.Type) map[string][]int {
+	tagged := map[string][]int{}
+	named := map[string][]int{}
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldIndex := append(append([]int{}, index...), i)
+			name := fieldTagName(field.Tag)
+			if name == "-" {
+				continue
+			}
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+				walk(fieldType, fieldIndex)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name != "" {
+				if _, ok := tagged[name]; !ok {
+					tagged[name] = fieldIndex
+				}
+				continue
+			}
+			if _, ok := named[field.Name]; !ok {
+				named[field.Name] = fieldIndex
+			}
+		}
+	}
+	walk(t, nil)
+	for name, index := range named {
+		if _, ok := tagged[name]; !ok {
+			tagged[name] = index
+		}
+	}
+	return tagged
+}
+
+func lookupDecodableField(fields map[string][]int, name string) ([]int, bool) {
+	if index, ok := fields[name]; ok {
+		return index, true
+	}
+	for fieldName, index := range fields {
+		if strings.EqualFold(fieldName, name) {
+			return index, true
+		}
+	}
+	return nil, false
+}
+
+// fieldTagName returns the name given to a struct field by its `graphql` tag,
+// falling back to its `json` tag.
+func fieldTagName(tag reflect.StructTag) string {
+	for _, key := range []string{"graphql", "json"} {
+		if name, _, _ := strings.Cut(tag.Get(key), ","); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// fieldByIndexAlloc is like reflect.Value.FieldByIndex, but allocates nil
+// embedded struct pointers along the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return v, fmt.Errorf("cannot set embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+func inputType(types map[string]Input, name string) Type {
+	if ttype, ok := types[name]; ok {
+		return ttype
+	}
+	return nil
+}
+
+func joinArgPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
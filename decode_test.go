@@ -0,0 +1,219 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/location"
+	"github.com/machship/graphql/testutil"
+)
+
+type decodeTestColor int
+
+const (
+	decodeTestRed decodeTestColor = iota
+	decodeTestGreen
+)
+
+type decodeTestItem struct {
+	SKU    string `json:"sku"`
+	Weight float64
+}
+
+type decodeTestAudit struct {
+	Note **string `graphql:"note"`
+}
+
+type decodeTestInput struct {
+	decodeTestAudit
+	Name     string           `json:"name"`
+	Color    decodeTestColor  `graphql:"color"`
+	Items    []decodeTestItem `json:"items"`
+	Tags     *[]string        `json:"tags"`
+	Deadline *time.Time       `json:"deadline"`
+}
+
+type decodeTestArgs struct {
+	ID    int             `graphql:"id"`
+	Input decodeTestInput `graphql:"input"`
+	Limit *int            `graphql:"limit"`
+}
+
+var decodeTestColorEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "DecodeTestColor",
+	Values: graphql.EnumValueConfigMap{
+		"RED":   &graphql.EnumValueConfig{Value: decodeTestRed},
+		"GREEN": &graphql.EnumValueConfig{Value: decodeTestGreen},
+	},
+})
+
+var decodeTestItemInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DecodeTestItemInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"sku":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"weight": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+	},
+})
+
+var decodeTestInputObject = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DecodeTestInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"note":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"color":    &graphql.InputObjectFieldConfig{Type: decodeTestColorEnum},
+		"items":    &graphql.InputObjectFieldConfig{Type: graphql.NewList(decodeTestItemInput)},
+		"tags":     &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)},
+		"deadline": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+	},
+})
+
+var decodeTestFieldArgs = graphql.FieldConfigArgument{
+	"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+	"input": &graphql.ArgumentConfig{Type: decodeTestInputObject},
+	"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+}
+
+func TestDecodeArgs_DecodesNestedInputObjects(t *testing.T) {
+	var args decodeTestArgs
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: decodeTestFieldArgs,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return "ok", p.DecodeArgs(&args)
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			test(id: 4, input: {
+				name: "parcel", note: null, color: GREEN,
+				items: [{sku: "a", weight: 1.5}, {sku: "b"}],
+				deadline: "2017-07-23T03:46:56.647Z"
+			})
+		}`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+
+	deadline := time.Date(2017, 7, 23, 3, 46, 56, 647000000, time.UTC)
+	var nullNote *string
+	expected := decodeTestArgs{
+		ID: 4,
+		Input: decodeTestInput{
+			decodeTestAudit: decodeTestAudit{Note: &nullNote},
+			Name:            "parcel",
+			Color:           decodeTestGreen,
+			Items:           []decodeTestItem{{SKU: "a", Weight: 1.5}, {SKU: "b"}},
+			Deadline:        &deadline,
+		},
+	}
+	if !reflect.DeepEqual(expected, args) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, args))
+	}
+	if args.Limit != nil || args.Input.Tags != nil {
+		t.Fatalf("expected absent arguments to stay nil, got %v and %v", args.Limit, args.Input.Tags)
+	}
+}
+
+func TestDecodeArgs_DistinguishesNullFromAbsentWithDoublePointers(t *testing.T) {
+	var notes []**string
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: decodeTestFieldArgs,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			args, err := graphql.DecodeArgs[decodeTestArgs](p)
+			notes = append(notes, args.Input.Note)
+			return "ok", err
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query ($note: String) {
+			absent: test(id: 1, input: {})
+			null: test(id: 2, input: {note: null})
+			value: test(id: 3, input: {note: $note})
+		}`,
+		VariableValues: map[string]any{"note": "hello"},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if len(notes) != 3 {
+		t.Fatalf("expected 3 resolved fields, got %v", len(notes))
+	}
+	if notes[0] != nil {
+		t.Fatalf("expected absent note to be nil, got %v", notes[0])
+	}
+	if notes[1] == nil || *notes[1] != nil {
+		t.Fatalf("expected null note to point to nil, got %v", notes[1])
+	}
+	if notes[2] == nil || *notes[2] == nil || **notes[2] != "hello" {
+		t.Fatalf("expected note value to be decoded, got %v", notes[2])
+	}
+}
+
+func TestDecodeArgs_ReturnsLocatedErrorOnMismatch(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: decodeTestFieldArgs,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			var args struct {
+				Input struct {
+					Items []struct {
+						SKU int `json:"sku"`
+					} `json:"items"`
+				} `json:"input"`
+			}
+			if err := p.DecodeArgs(&args); err != nil {
+				return nil, err
+			}
+			return "ok", nil
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test(id: 1, input: {items: [{sku: "a"}]}) }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"test": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   `Could not decode argument "input.items[0].sku": cannot assign string to int.`,
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []any{"test"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDecodeArgs_RequiresPointer(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.String,
+		Args: decodeTestFieldArgs,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if err := p.DecodeArgs(decodeTestArgs{}); err != nil {
+				return nil, err
+			}
+			return "ok", nil
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test(id: 1) }`,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got %v", result.Errors)
+	}
+	if expected := "DecodeArgs requires a non-nil pointer, got graphql_test.decodeTestArgs"; result.Errors[0].Message != expected {
+		t.Fatalf("expected error %q, got %q", expected, result.Errors[0].Message)
+	}
+}
@@ -0,0 +1,199 @@
+package graphql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	resolveParamsType = reflect.TypeOf(ResolveParams{})
+	contextType       = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+
+	// cache of defaultResolvePlans, keyed by defaultResolvePlanKey
+	defaultResolvePlans sync.Map
+)
+
+type defaultResolvePlanKey struct {
+	sourceType reflect.Type
+	fieldName  string
+	methods    bool
+}
+
+// methodArgs describes the argument a getter method used by MethodResolveFn
+// accepts.
+type methodArgs int
+
+const (
+	methodArgsNone methodArgs = iota
+	methodArgsResolveParams
+	methodArgsContext
+)
+
+// defaultResolvePlan describes how DefaultResolveFn or MethodResolveFn
+// resolves a field name on values of a given type: either by reading a
+// struct field, by calling a getter method, or not at all.
+type defaultResolvePlan struct {
+	// index path of the struct field, through embedded structs
+	fieldIndex []int
+	// getter method, valid if method.Func is valid
+	method     reflect.Method
+	methodArgs methodArgs
+	// whether the method returns an error as its second result
+	methodErr bool
+}
+
+// defaultResolvePlanFor returns the cached plan for resolving fieldName on
+// values of sourceType, building it on first use. Getter methods are only
+// considered if methods is set.
+func defaultResolvePlanFor(sourceType reflect.Type, fieldName string, methods bool) *defaultResolvePlan {
+	key := defaultResolvePlanKey{sourceType: sourceType, fieldName: fieldName, methods: methods}
+	if plan, ok := defaultResolvePlans.Load(key); ok {
+		return plan.(*defaultResolvePlan)
+	}
+	plan, _ := defaultResolvePlans.LoadOrStore(key, buildDefaultResolvePlan(sourceType, fieldName, methods))
+	return plan.(*defaultResolvePlan)
+}
+
+func buildDefaultResolvePlan(sourceType reflect.Type, fieldName string, methods bool) *defaultResolvePlan {
+	structType := sourceType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() == reflect.Struct {
+		if index := findResolvableField(structType, fieldName); index != nil {
+			return &defaultResolvePlan{fieldIndex: index}
+		}
+	}
+	if !methods {
+		return &defaultResolvePlan{}
+	}
+	for _, name := range []string{fieldName, "Get" + fieldName} {
+		for i := 0; i < sourceType.NumMethod(); i++ {
+			method := sourceType.Method(i)
+			if !strings.EqualFold(method.Name, name) {
+				continue
+			}
+			if plan, ok := getterMethodPlan(method); ok {
+				return plan
+			}
+		}
+	}
+	return &defaultResolvePlan{}
+}
+
+// findResolvableField returns the index path of the shallowest exported
+// struct field whose name matches fieldName case-insensitively or whose
+// `json` or `graphql` tag names it. Fields at the same depth are matched in
+// declaration order.
+func findResolvableField(structType reflect.Type, fieldName string) []int {
+	type candidate struct {
+		structType reflect.Type
+		index      []int
+	}
+	current := []candidate{{structType: structType}}
+	visited := map[reflect.Type]bool{}
+	for len(current) > 0 {
+		var next []candidate
+		for _, c := range current {
+			if visited[c.structType] {
+				continue
+			}
+			visited[c.structType] = true
+			for i := 0; i < c.structType.NumField(); i++ {
+				field := c.structType.Field(i)
+				index := append(append([]int{}, c.index...), i)
+				if field.IsExported() && (strings.EqualFold(field.Name, fieldName) ||
+					tagNameMatches(field.Tag, "json", fieldName) ||
+					tagNameMatches(field.Tag, "graphql", fieldName)) {
+					return index
+				}
+				if field.Anonymous {
+					embedded := field.Type
+					if embedded.Kind() == reflect.Ptr {
+						embedded = embedded.Elem()
+					}
+					if embedded.Kind() == reflect.Struct {
+						next = append(next, candidate{structType: embedded, index: index})
+					}
+				}
+			}
+		}
+		current = next
+	}
+	return nil
+}
+
+func tagNameMatches(tag reflect.StructTag, key string, fieldName string) bool {
+	name, _, _ := strings.Cut(tag.Get(key), ",")
+	return name == fieldName
+}
+
+// getterMethodPlan returns a plan calling method if its signature is one
+// supported by MethodResolveFn.
+func getterMethodPlan(method reflect.Method) (*defaultResolvePlan, bool) {
+	methodType := method.Type
+	plan := &defaultResolvePlan{method: method}
+	// the receiver is the first argument
+	switch methodType.NumIn() {
+	case 1:
+		plan.methodArgs = methodArgsNone
+	case 2:
+		switch methodType.In(1) {
+		case resolveParamsType:
+			plan.methodArgs = methodArgsResolveParams
+		case contextType:
+			plan.methodArgs = methodArgsContext
+		default:
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	switch {
+	case methodType.NumOut() == 1:
+	case methodType.NumOut() == 2 && methodType.Out(1) == errorType:
+		plan.methodErr = true
+	default:
+		return nil, false
+	}
+	return plan, true
+}
+
+// resolve applies the plan to sourceVal, which must be of the type the plan
+// was built for.
+func (plan *defaultResolvePlan) resolve(sourceVal reflect.Value, p ResolveParams) (any, error) {
+	if plan.fieldIndex != nil {
+		if sourceVal.Kind() == reflect.Ptr {
+			sourceVal = sourceVal.Elem()
+		}
+		field, err := sourceVal.FieldByIndexErr(plan.fieldIndex)
+		if err != nil {
+			// nil embedded struct pointer
+			return nil, nil
+		}
+		return field.Interface(), nil
+	}
+	if !plan.method.Func.IsValid() {
+		return nil, nil
+	}
+
+	in := []reflect.Value{sourceVal}
+	switch plan.methodArgs {
+	case methodArgsResolveParams:
+		in = append(in, reflect.ValueOf(p))
+	case methodArgsContext:
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		in = append(in, reflect.ValueOf(&ctx).Elem())
+	}
+	out := plan.method.Func.Call(in)
+	if plan.methodErr && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+	return out[0].Interface(), nil
+}
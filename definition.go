@@ -353,12 +353,14 @@ type Object struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	IsTypeOf           IsTypeOfFn
+	Authorize          AuthorizeFn
 
 	typeConfig            ObjectConfig
 	initialisedFields     bool
 	fields                FieldDefinitionMap
 	initialisedInterfaces bool
 	interfaces            []*Interface
+	goTypes               []reflect.Type
 	// Interim alternative to throwing an error during schema definition at run-time
 	err error
 
@@ -393,6 +395,18 @@ type ObjectConfig struct {
 	IsTypeOf    IsTypeOfFn `json:"isTypeOf"`
 	Description string     `json:"description"`
 	Directives  []*AppliedDirective
+
+	// GoTypes optionally declares the Go types whose values this object
+	// represents, given as example values (e.g. &User{} or User{}) or as
+	// reflect.Type. When a Union or Interface without a ResolveType function
+	// resolves a value, the object declaring the value's Go type is used
+	// without calling any IsTypeOf function. Declaring a struct type also
+	// matches pointers to it.
+	GoTypes []any `json:"-"`
+
+	// Authorize, if set, authorizes access to values of the object, wherever
+	// they are reached, in place of an applied @auth directive.
+	Authorize AuthorizeFn `json:"-"`
 }
 
 type FieldsThunk func() Fields
@@ -416,9 +430,23 @@ func NewObject(config ObjectConfig) *Object {
 	objectType.PrivateName = config.Name
 	objectType.PrivateDescription = config.Description
 	objectType.IsTypeOf = config.IsTypeOf
+	objectType.Authorize = config.Authorize
 	objectType.typeConfig = config
 	objectType.directives = config.Directives
 
+	for _, goType := range config.GoTypes {
+		if t, ok := goType.(reflect.Type); ok {
+			objectType.goTypes = append(objectType.goTypes, t)
+			continue
+		}
+		err = invariantf(goType != nil, `%v.GoTypes must not contain nil.`, config.Name)
+		if err != nil {
+			objectType.err = err
+			return objectType
+		}
+		objectType.goTypes = append(objectType.goTypes, reflect.TypeOf(goType))
+	}
+
 	return objectType
 }
 
@@ -507,6 +535,11 @@ func (gt *Object) Error() error {
 	return gt.err
 }
 
+// GoTypes returns the Go types declared by ObjectConfig.GoTypes.
+func (gt *Object) GoTypes() []reflect.Type {
+	return gt.goTypes
+}
+
 func (o *Object) AppliedDirectives() []*AppliedDirective {
 	return o.directives
 }
@@ -579,6 +612,8 @@ func defineFieldMap(ttype Named, fieldMap Fields) (FieldDefinitionMap, error) {
 			Subscribe:         field.Subscribe,
 			DeprecationReason: field.DeprecationReason,
 			Directives:        field.Directives,
+			Cost:              field.Cost,
+			Authorize:         field.Authorize,
 		}
 
 		fieldDef.Args = []*Argument{}
@@ -654,6 +689,12 @@ type Field struct {
 	DeprecationReason string              `json:"deprecationReason"`
 	Description       string              `json:"description"`
 	Directives        []*AppliedDirective
+	// Cost is the cost of the field for CostRule, in place of an applied
+	// @cost directive.
+	Cost *FieldCost `json:"-"`
+	// Authorize, if set, authorizes access to the field before resolving
+	// it, in place of an applied @auth directive.
+	Authorize AuthorizeFn `json:"-"`
 }
 
 type FieldConfigArgument map[string]*ArgumentConfig
@@ -674,6 +715,8 @@ type FieldDefinition struct {
 	Subscribe         FieldResolveFn `json:"-"`
 	DeprecationReason string         `json:"deprecationReason"`
 	Directives        []*AppliedDirective
+	Cost              *FieldCost  `json:"-"`
+	Authorize         AuthorizeFn `json:"-"`
 }
 
 func (f *FieldDefinition) AppliedDirectives() []*AppliedDirective {
@@ -732,6 +775,7 @@ type Interface struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	ResolveType        ResolveTypeFn
+	Authorize          AuthorizeFn
 
 	typeConfig        InterfaceConfig
 	initialisedFields bool
@@ -745,6 +789,10 @@ type InterfaceConfig struct {
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
 	Directives  []*AppliedDirective
+
+	// Authorize, if set, authorizes access to values of the objects
+	// implementing the interface, in place of an applied @auth directive.
+	Authorize AuthorizeFn `json:"-"`
 }
 
 // ResolveTypeParams Params for ResolveTypeFn()
@@ -776,6 +824,7 @@ func NewInterface(config InterfaceConfig) *Interface {
 	it.PrivateName = config.Name
 	it.PrivateDescription = config.Description
 	it.ResolveType = config.ResolveType
+	it.Authorize = config.Authorize
 	it.typeConfig = config
 	it.directives = config.Directives
 
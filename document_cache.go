@@ -0,0 +1,137 @@
+package graphql
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+
+	"github.com/machship/graphql/language/ast"
+)
+
+// DocumentCache caches the parsed and validated documents of requests, so
+// that Do can skip parsing and validating requests it has seen before.
+// Implementations must be safe for concurrent use.
+type DocumentCache interface {
+	Get(key DocumentCacheKey) (*CachedDocument, bool)
+	Add(key DocumentCacheKey, document *CachedDocument)
+}
+
+// DocumentCacheKey identifies a request string validated against a schema.
+type DocumentCacheKey struct {
+	// SchemaID identifies the schema the document was validated against. It
+	// changes when types are added to the schema.
+	SchemaID uint64
+	// Hash is the SHA-256 hash of the request string.
+	Hash [sha256.Size]byte
+}
+
+// CachedDocument is a parsed document together with the result of its
+// validation. Both must be treated as read-only, as they are shared by all
+// requests with the same key.
+type CachedDocument struct {
+	Document   *ast.Document
+	Validation ValidationResult
+}
+
+// DocumentCacheStats are the counters of an LRUDocumentCache.
+type DocumentCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// LRUDocumentCache is a DocumentCache holding up to a fixed number of
+// documents, evicting the least recently used one when full.
+type LRUDocumentCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[DocumentCacheKey]*list.Element
+	// most recently used first
+	recency *list.List
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+type lruDocumentCacheEntry struct {
+	key      DocumentCacheKey
+	document *CachedDocument
+}
+
+// NewLRUDocumentCache returns an LRUDocumentCache holding up to size
+// documents.
+func NewLRUDocumentCache(size int) *LRUDocumentCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUDocumentCache{
+		size:    size,
+		entries: map[DocumentCacheKey]*list.Element{},
+		recency: list.New(),
+	}
+}
+
+func (c *LRUDocumentCache) Get(key DocumentCacheKey) (*CachedDocument, bool) {
+	c.mu.Lock()
+	element, ok := c.entries[key]
+	var document *CachedDocument
+	if ok {
+		c.recency.MoveToFront(element)
+		document = element.Value.(*lruDocumentCacheEntry).document
+	}
+	c.mu.Unlock()
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return document, true
+}
+
+func (c *LRUDocumentCache) Add(key DocumentCacheKey, document *CachedDocument) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruDocumentCacheEntry).document = document
+		c.recency.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.recency.PushFront(&lruDocumentCacheEntry{key: key, document: document})
+	for c.recency.Len() > c.size {
+		oldest := c.recency.Back()
+		c.recency.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruDocumentCacheEntry).key)
+		c.evictions.Add(1)
+	}
+}
+
+// Len returns the number of cached documents.
+func (c *LRUDocumentCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recency.Len()
+}
+
+// Stats returns the hit, miss and eviction counters of the cache.
+func (c *LRUDocumentCache) Stats() DocumentCacheStats {
+	return DocumentCacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+// newDocumentCacheKey returns the key of requestString validated against
+// schema.
+func newDocumentCacheKey(schema *Schema, requestString string) DocumentCacheKey {
+	return DocumentCacheKey{
+		SchemaID: schema.id,
+		Hash:     sha256.Sum256([]byte(requestString)),
+	}
+}
+
+// schemaIDs generates the identities of schemas
+var schemaIDs atomic.Uint64
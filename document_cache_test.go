@@ -0,0 +1,154 @@
+package graphql_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/testutil"
+	"github.com/machship/graphql/testutil/starwars"
+)
+
+func TestDo_UsesDocumentCache(t *testing.T) {
+	cache := graphql.NewLRUDocumentCache(10)
+	schema := starwars.Schema
+	expected := map[string]any{
+		"hero": map[string]any{"name": "R2-D2"},
+	}
+	for i := 0; i < 3; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ hero { name } }`,
+			DocumentCache: cache,
+		})
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		if !reflect.DeepEqual(expected, result.Data) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+		}
+	}
+	if stats := cache.Stats(); stats != (graphql.DocumentCacheStats{Hits: 2, Misses: 1}) {
+		t.Fatalf("Unexpected cache stats: %+v", stats)
+	}
+}
+
+func TestDo_CachesValidationErrors(t *testing.T) {
+	cache := graphql.NewLRUDocumentCache(10)
+	schema := starwars.Schema
+	var messages []string
+	for i := 0; i < 2; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ hero { unknown } }`,
+			DocumentCache: cache,
+		})
+		if len(result.Errors) != 1 {
+			t.Fatalf("expected one error, got %v", result.Errors)
+		}
+		messages = append(messages, result.Errors[0].Message)
+	}
+	if messages[0] != messages[1] || messages[0] != `Cannot query field "unknown" on type "Character".` {
+		t.Fatalf("Unexpected errors: %v", messages)
+	}
+	if stats := cache.Stats(); stats.Hits != 1 {
+		t.Fatalf("expected the invalid document to be cached, got %+v", stats)
+	}
+}
+
+func TestDo_DocumentCacheIsKeyedBySchema(t *testing.T) {
+	cache := graphql.NewLRUDocumentCache(10)
+	for _, schema := range []graphql.Schema{starwars.Schema, testSchema(t, &graphql.Field{Type: graphql.String})} {
+		graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ hero { name } }`,
+			DocumentCache: cache,
+		})
+	}
+	if stats := cache.Stats(); stats.Misses != 2 || cache.Len() != 2 {
+		t.Fatalf("expected a cache entry per schema, got %+v", stats)
+	}
+}
+
+func TestLRUDocumentCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := graphql.NewLRUDocumentCache(2)
+	keys := []graphql.DocumentCacheKey{{Hash: [32]byte{1}}, {Hash: [32]byte{2}}, {Hash: [32]byte{3}}}
+	cache.Add(keys[0], &graphql.CachedDocument{})
+	cache.Add(keys[1], &graphql.CachedDocument{})
+	cache.Get(keys[0])
+	cache.Add(keys[2], &graphql.CachedDocument{})
+	if _, ok := cache.Get(keys[1]); ok {
+		t.Fatalf("expected the least recently used document to be evicted")
+	}
+	for _, key := range []graphql.DocumentCacheKey{keys[0], keys[2]} {
+		if _, ok := cache.Get(key); !ok {
+			t.Fatalf("expected %v to be cached", key.Hash[0])
+		}
+	}
+	if stats := cache.Stats(); stats != (graphql.DocumentCacheStats{Hits: 3, Misses: 1, Evictions: 1}) {
+		t.Fatalf("Unexpected cache stats: %+v", stats)
+	}
+}
+
+func TestLRUDocumentCache_GetAndAddConcurrently(t *testing.T) {
+	cache := graphql.NewLRUDocumentCache(10)
+	key := graphql.DocumentCacheKey{Hash: [32]byte{1}}
+	cache.Add(key, &graphql.CachedDocument{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cache.Add(key, &graphql.CachedDocument{})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if document, ok := cache.Get(key); !ok || document == nil {
+					t.Errorf("expected the document to be cached")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type mapDocumentCache struct {
+	sync.Map
+	adds int
+}
+
+func (c *mapDocumentCache) Get(key graphql.DocumentCacheKey) (*graphql.CachedDocument, bool) {
+	document, ok := c.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return document.(*graphql.CachedDocument), true
+}
+
+func (c *mapDocumentCache) Add(key graphql.DocumentCacheKey, document *graphql.CachedDocument) {
+	c.adds++
+	c.Store(key, document)
+}
+
+func TestDo_UsesCustomDocumentCache(t *testing.T) {
+	cache := &mapDocumentCache{}
+	schema := starwars.Schema
+	for i := 0; i < 3; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ hero { id } }`,
+			DocumentCache: cache,
+		})
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+	}
+	if cache.adds != 1 {
+		t.Fatalf("expected the document to be added once, got %v", cache.adds)
+	}
+}
@@ -0,0 +1,151 @@
+package graphql
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/machship/graphql/gqlerrors"
+)
+
+// PresentErrorParams is passed to an ErrorPresenterFn.
+type PresentErrorParams struct {
+	// Error is the error as it is sent to the client without a presenter,
+	// including its locations and path.
+	Error gqlerrors.FormattedError
+
+	// OriginalError is the error returned by the resolve function, or the
+	// value it panicked with as an error. For errors raised by the executor
+	// itself, e.g. for a null value of a non-null field, it is the
+	// gqlerrors.FormattedError describing them.
+	OriginalError error
+
+	// Path is the response path of the field the error was raised for.
+	Path []any
+
+	// Context is the context of the request.
+	Context context.Context
+}
+
+// ErrorPresenterFn turns an error raised while executing a field into the
+// error sent to the client.
+type ErrorPresenterFn func(p PresentErrorParams) gqlerrors.FormattedError
+
+// ErrorReporterFn receives the errors masked by MaskingErrorPresenter,
+// together with the correlation ID sent to the client in their place.
+type ErrorReporterFn func(p PresentErrorParams, correlationID string)
+
+// MaskedErrorMessage is the message of the errors masked by
+// MaskingErrorPresenter.
+const MaskedErrorMessage = "Internal server error"
+
+// MaskingErrorPresenter returns an ErrorPresenterFn suited for production,
+// which replaces errors that are neither gqlerrors.ExtendedError
+// implementations nor errors raised by the executor itself with an
+// "Internal server error", so that e.g. database errors and panic messages
+// do not reach clients. The masked error carries a random correlation ID in
+// its "correlationId" extension, and report, if not nil, is called with the
+// original error and the same ID so that it can be logged.
+//
+// The errors raised by the executor itself are gqlerrors.FormattedError
+// values describing how the result of a resolver violates the schema, e.g.
+// "Cannot return null for non-nullable field" or "expected iterable", and
+// are never masked. All other errors returned by or recovered from
+// resolvers are masked, including those of resolvers built by NewField or
+// calling DecodeArgs for sources or arguments not matching their Go types,
+// as those point at bugs of the server.
+func MaskingErrorPresenter(report ErrorReporterFn) ErrorPresenterFn {
+	return func(p PresentErrorParams) gqlerrors.FormattedError {
+		if !isInternalError(p.OriginalError) {
+			return p.Error
+		}
+		correlationID := newCorrelationID()
+		if report != nil {
+			report(p, correlationID)
+		}
+		masked := p.Error
+		masked.Message = MaskedErrorMessage
+		masked.Extensions = map[string]any{
+			"correlationId": correlationID,
+		}
+		return masked
+	}
+}
+
+// isInternalError reports whether err is an error which is not meant to be
+// shown to clients, i.e. neither a GraphQL error nor an ExtendedError.
+func isInternalError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch err.(type) {
+	case gqlerrors.FormattedError, *gqlerrors.Error, gqlerrors.Error:
+		return false
+	}
+	var extended gqlerrors.ExtendedError
+	return !errors.As(err, &extended)
+}
+
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// originalError returns the innermost error wrapped by the located and
+// formatted errors the executor builds around the errors of resolvers.
+func originalError(err error) error {
+	for {
+		switch e := err.(type) {
+		case *gqlerrors.Error:
+			if e.OriginalError == nil {
+				return e
+			}
+			err = e.OriginalError
+		case gqlerrors.Error:
+			if e.OriginalError == nil {
+				return e
+			}
+			err = e.OriginalError
+		case gqlerrors.FormattedError:
+			if e.OriginalError() == nil {
+				return e
+			}
+			err = e.OriginalError()
+		default:
+			return err
+		}
+	}
+}
+
+// presentErrors formats a located error raised while executing a field,
+// expanding it into several errors if it holds several, e.g. a MultiError.
+func presentErrors(eCtx *executionContext, err *gqlerrors.Error) []gqlerrors.FormattedError {
+	formatted := []gqlerrors.FormattedError{}
+	for _, err := range expandError(err) {
+		formatted = append(formatted, presentError(eCtx, err))
+	}
+	return formatted
+}
+
+// presentError formats an error raised while executing a field, passing it
+// to the error presenter of the execution if there is one.
+func presentError(eCtx *executionContext, err error) gqlerrors.FormattedError {
+	formatted := gqlerrors.FormatError(err)
+	if eCtx == nil {
+		return formatted
+	}
+	if eCtx.ErrorPresenter != nil {
+		formatted = eCtx.ErrorPresenter(PresentErrorParams{
+			Error:         formatted,
+			OriginalError: originalError(err),
+			Path:          formatted.Path,
+			Context:       eCtx.Context,
+		})
+	}
+	if eCtx.Debug {
+		formatted = withStackTrace(formatted, err)
+	}
+	return formatted
+}
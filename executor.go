@@ -22,6 +22,31 @@ type ExecuteParams struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// ErrorPresenter, if set, is used instead of the ErrorPresenter of the
+	// schema to present the errors raised while executing fields.
+	ErrorPresenter ErrorPresenterFn
+
+	// Debug exposes the stack traces of recovered panics in the "stacktrace"
+	// extension of the corresponding errors. It should not be enabled in
+	// production.
+	Debug bool
+
+	// MutationHooks, if set, are used instead of the MutationHooks of the
+	// schema around the root fields of mutations.
+	MutationHooks *MutationHooks
+
+	// IntrospectionPolicy, if set, is used instead of the IntrospectionPolicy
+	// of the schema to restrict introspection.
+	IntrospectionPolicy IntrospectionPolicyFn
+
+	// OrderedResult completes the objects of Data as *OrderedObject rather
+	// than map[string]any, so that marshalling the Result writes their fields
+	// in query order rather than sorted.
+	OrderedResult bool
+
+	// prepared operation being executed, if any
+	prepared *PreparedOperation
 }
 
 func Execute(p ExecuteParams) (result *Result) {
@@ -51,26 +76,33 @@ func Execute(p ExecuteParams) (result *Result) {
 
 	go func() {
 		result := &Result{}
+		var exeContext *executionContext
 
 		defer func() {
-			if err := recover(); err != nil {
-				result.Errors = append(result.Errors, gqlerrors.FormatError(err.(error)))
+			if r := recover(); r != nil {
+				result.Errors = append(result.Errors, presentErrors(exeContext, recoveredError(r, nil, nil))...)
 			}
 			resultChannel <- result
 		}()
 
 		exeContext, err := buildExecutionContext(buildExecutionCtxParams{
-			Schema:        p.Schema,
-			Root:          p.Root,
-			AST:           p.AST,
-			OperationName: p.OperationName,
-			Args:          p.Args,
-			Result:        result,
-			Context:       p.Context,
+			Schema:              p.Schema,
+			Root:                p.Root,
+			AST:                 p.AST,
+			OperationName:       p.OperationName,
+			Args:                p.Args,
+			Result:              result,
+			Context:             p.Context,
+			ErrorPresenter:      p.ErrorPresenter,
+			Debug:               p.Debug,
+			MutationHooks:       p.MutationHooks,
+			OrderedResult:       p.OrderedResult,
+			IntrospectionPolicy: p.IntrospectionPolicy,
+			prepared:            p.prepared,
 		})
 
 		if err != nil {
-			result.Errors = append(result.Errors, gqlerrors.FormatError(err))
+			result.Errors = append(result.Errors, formatMultiError(err)...)
 			resultChannel <- result
 			return
 		}
@@ -93,13 +125,19 @@ func Execute(p ExecuteParams) (result *Result) {
 }
 
 type buildExecutionCtxParams struct {
-	Schema        Schema
-	Root          any
-	AST           *ast.Document
-	OperationName string
-	Args          map[string]any
-	Result        *Result
-	Context       context.Context
+	Schema              Schema
+	Root                any
+	AST                 *ast.Document
+	OperationName       string
+	Args                map[string]any
+	Result              *Result
+	Context             context.Context
+	ErrorPresenter      ErrorPresenterFn
+	Debug               bool
+	MutationHooks       *MutationHooks
+	OrderedResult       bool
+	IntrospectionPolicy IntrospectionPolicyFn
+	prepared            *PreparedOperation
 }
 
 type executionContext struct {
@@ -110,6 +148,18 @@ type executionContext struct {
 	VariableValues map[string]any
 	Errors         []gqlerrors.FormattedError
 	Context        context.Context
+	ErrorPresenter ErrorPresenterFn
+	Debug          bool
+	MutationHooks  *MutationHooks
+
+	// introspection allowed for the request
+	introspectionPolicy IntrospectionPolicy
+
+	// whether objects are completed as *OrderedObject
+	orderedResult bool
+
+	// prepared operation being executed, if any
+	prepared *PreparedOperation
 }
 
 func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error) {
@@ -155,6 +205,18 @@ func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error)
 	eCtx.Operation = operation
 	eCtx.VariableValues = variableValues
 	eCtx.Context = p.Context
+	eCtx.Debug = p.Debug
+	eCtx.orderedResult = p.OrderedResult
+	eCtx.prepared = p.prepared
+	eCtx.ErrorPresenter = p.ErrorPresenter
+	if eCtx.ErrorPresenter == nil {
+		eCtx.ErrorPresenter = p.Schema.errorPresenter
+	}
+	eCtx.MutationHooks = p.MutationHooks
+	if eCtx.MutationHooks == nil {
+		eCtx.MutationHooks = p.Schema.mutationHooks
+	}
+	eCtx.introspectionPolicy = introspectionPolicy(&p.Schema, p.IntrospectionPolicy, p.Context)
 	return eCtx, nil
 }
 
@@ -170,17 +232,20 @@ func executeOperation(p executeOperationParams) *Result {
 		return &Result{Errors: gqlerrors.FormatErrors(err)}
 	}
 
-	fields := collectFields(collectFieldsParams{
-		ExeContext:   p.ExecutionContext,
-		RuntimeType:  operationType,
-		SelectionSet: p.Operation.GetSelectionSet(),
+	plan := fieldPlan(p.ExecutionContext, operationType, nil, func(usesVariables *bool) map[string][]*ast.Field {
+		return collectFields(collectFieldsParams{
+			ExeContext:    p.ExecutionContext,
+			RuntimeType:   operationType,
+			SelectionSet:  p.Operation.GetSelectionSet(),
+			UsesVariables: usesVariables,
+		})
 	})
 
 	executeFieldsParams := executeFieldsParams{
 		ExecutionContext: p.ExecutionContext,
 		ParentType:       operationType,
 		Source:           p.Root,
-		Fields:           fields,
+		Plan:             plan,
 	}
 
 	if p.Operation.GetOperation() == ast.OperationTypeMutation {
@@ -242,6 +307,16 @@ type executeFieldsParams struct {
 	Source           any
 	Fields           map[string][]*ast.Field
 	Path             *ResponsePath
+	// Plan, if set, holds the ordered fields to execute instead of Fields
+	Plan []*orderedField
+}
+
+// plan returns the ordered fields to execute.
+func (p executeFieldsParams) plan() []*orderedField {
+	if p.Plan != nil {
+		return p.Plan
+	}
+	return orderedFields(p.Fields)
 }
 
 // Implements the "Evaluating selection sets" section of the spec for "write" mode.
@@ -253,12 +328,15 @@ func executeFieldsSerially(p executeFieldsParams) *Result {
 		p.Fields = map[string][]*ast.Field{}
 	}
 
-	finalResults := make(map[string]any, len(p.Fields))
-	for _, orderedField := range orderedFields(p.Fields) {
+	plan := p.plan()
+	if hooks := p.ExecutionContext.MutationHooks; hooks != nil {
+		return executeFieldsWithMutationHooks(p, plan, hooks)
+	}
+	finalResults := make(map[string]any, len(plan))
+	for _, orderedField := range plan {
 		responseName := orderedField.responseName
-		fieldASTs := orderedField.fieldASTs
 		fieldPath := p.Path.WithKey(responseName)
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs, fieldPath)
+		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, orderedField, fieldPath)
 		if state.hasNoFieldDefs {
 			continue
 		}
@@ -267,19 +345,20 @@ func executeFieldsSerially(p executeFieldsParams) *Result {
 	dethunkMapDepthFirst(finalResults)
 
 	return &Result{
-		Data:   finalResults,
+		Data:   newObject(p.ExecutionContext, finalResults, plan),
 		Errors: p.ExecutionContext.Errors,
 	}
 }
 
 // Implements the "Evaluating selection sets" section of the spec for "read" mode.
 func executeFields(p executeFieldsParams) *Result {
+	p.Plan = p.plan()
 	finalResults := executeSubFields(p)
 
 	dethunkMapWithBreadthFirstTraversal(finalResults)
 
 	return &Result{
-		Data:   finalResults,
+		Data:   newObject(p.ExecutionContext, finalResults, p.Plan),
 		Errors: p.ExecutionContext.Errors,
 	}
 }
@@ -293,10 +372,11 @@ func executeSubFields(p executeFieldsParams) map[string]any {
 		p.Fields = map[string][]*ast.Field{}
 	}
 
-	finalResults := make(map[string]any, len(p.Fields))
-	for _, orderedField := range orderedFields(p.Fields) {
+	plan := p.plan()
+	finalResults := make(map[string]any, len(plan))
+	for _, orderedField := range plan {
 		fieldPath := p.Path.WithKey(orderedField.responseName)
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, orderedField.fieldASTs, fieldPath)
+		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, orderedField, fieldPath)
 		if state.hasNoFieldDefs {
 			continue
 		}
@@ -343,6 +423,8 @@ func dethunkMapBreadthFirst(m map[string]any, dethunkQueue *dethunkQueue) {
 		switch val := m[k].(type) {
 		case map[string]any:
 			dethunkQueue.push(func() { dethunkMapBreadthFirst(val, dethunkQueue) })
+		case *OrderedObject:
+			dethunkQueue.push(func() { dethunkMapBreadthFirst(val.Values, dethunkQueue) })
 		case []any:
 			dethunkQueue.push(func() { dethunkListBreadthFirst(val, dethunkQueue) })
 		}
@@ -357,6 +439,8 @@ func dethunkListBreadthFirst(list []any, dethunkQueue *dethunkQueue) {
 		switch val := list[i].(type) {
 		case map[string]any:
 			dethunkQueue.push(func() { dethunkMapBreadthFirst(val, dethunkQueue) })
+		case *OrderedObject:
+			dethunkQueue.push(func() { dethunkMapBreadthFirst(val.Values, dethunkQueue) })
 		case []any:
 			dethunkQueue.push(func() { dethunkListBreadthFirst(val, dethunkQueue) })
 		}
@@ -375,6 +459,8 @@ func dethunkMapDepthFirst(m map[string]any) {
 		switch val := m[k].(type) {
 		case map[string]any:
 			dethunkMapDepthFirst(val)
+		case *OrderedObject:
+			dethunkMapDepthFirst(val.Values)
 		case []any:
 			dethunkListDepthFirst(val)
 		}
@@ -389,6 +475,8 @@ func dethunkListDepthFirst(list []any) {
 		switch val := list[i].(type) {
 		case map[string]any:
 			dethunkMapDepthFirst(val)
+		case *OrderedObject:
+			dethunkMapDepthFirst(val.Values)
 		case []any:
 			dethunkListDepthFirst(val)
 		}
@@ -401,6 +489,9 @@ type collectFieldsParams struct {
 	SelectionSet         *ast.SelectionSet
 	Fields               map[string][]*ast.Field
 	VisitedFragmentNames map[string]bool
+	// UsesVariables, if set, is set to true if the collected fields depend on
+	// variables through @skip or @include
+	UsesVariables *bool
 }
 
 // Given a selectionSet, adds all of the fields in that selection to
@@ -421,6 +512,9 @@ func collectFields(p collectFieldsParams) (fields map[string][]*ast.Field) {
 		p.VisitedFragmentNames = map[string]bool{}
 	}
 	for _, iSelection := range p.SelectionSet.Selections {
+		if p.UsesVariables != nil && conditionUsesVariables(selectionDirectives(iSelection)) {
+			*p.UsesVariables = true
+		}
 		switch selection := iSelection.(type) {
 		case *ast.Field:
 			if !shouldIncludeNode(p.ExeContext, selection.Directives) {
@@ -443,6 +537,7 @@ func collectFields(p collectFieldsParams) (fields map[string][]*ast.Field) {
 				SelectionSet:         selection.SelectionSet,
 				Fields:               fields,
 				VisitedFragmentNames: p.VisitedFragmentNames,
+				UsesVariables:        p.UsesVariables,
 			}
 			collectFields(innerParams)
 		case *ast.FragmentSpread:
@@ -470,6 +565,7 @@ func collectFields(p collectFieldsParams) (fields map[string][]*ast.Field) {
 					SelectionSet:         fragment.GetSelectionSet(),
 					Fields:               fields,
 					VisitedFragmentNames: p.VisitedFragmentNames,
+					UsesVariables:        p.UsesVariables,
 				}
 				collectFields(innerParams)
 			}
@@ -593,31 +689,34 @@ type resolveFieldResultState struct {
 	hasNoFieldDefs bool
 }
 
+// handleFieldError handles a value recovered from a panic while resolving or
+// completing a field. It must be called from the deferred function which
+// recovered r.
 func handleFieldError(r any, fieldNodes []ast.Node, path *ResponsePath, returnType Output, eCtx *executionContext) {
-	err := NewLocatedErrorWithPath(r, fieldNodes, path.AsArray())
+	err := recoveredError(r, fieldNodes, path.AsArray())
 	// send panic upstream
 	if _, ok := returnType.(*NonNull); ok {
 		panic(err)
 	}
-	eCtx.Errors = append(eCtx.Errors, gqlerrors.FormatError(err))
+	eCtx.Errors = append(eCtx.Errors, presentErrors(eCtx, err)...)
 }
 
 // Resolves the field on the given source object. In particular, this
 // figures out the value that the field returns by calling its resolve function,
 // then calls completeValue to complete promises, serialize scalars, or execute
 // the sub-selection-set for objects.
-func resolveField(eCtx *executionContext, parentType *Object, source any, fieldASTs []*ast.Field, path *ResponsePath) (result any, resultState resolveFieldResultState) {
+func resolveField(eCtx *executionContext, parentType *Object, source any, field *orderedField, path *ResponsePath) (result any, resultState resolveFieldResultState) {
 	// catch panic from resolveFn
 	var returnType Output
 	defer func() (any, resolveFieldResultState) {
 		if r := recover(); r != nil {
-			handleFieldError(r, FieldASTsToNodeASTs(fieldASTs), path, returnType, eCtx)
+			handleFieldError(r, FieldASTsToNodeASTs(field.fieldASTs), path, returnType, eCtx)
 			return result, resultState
 		}
 		return result, resultState
 	}()
 
-	fieldAST := fieldASTs[0]
+	fieldAST := field.fieldASTs[0]
 	fieldName := ""
 	if fieldAST.Name != nil {
 		fieldName = fieldAST.Name.Value
@@ -632,6 +731,9 @@ func resolveField(eCtx *executionContext, parentType *Object, source any, fieldA
 	resolveFn := fieldDef.Resolve
 	if resolveFn == nil {
 		resolveFn = DefaultResolveFn
+		if eCtx.Schema.resolveMethods && !strings.HasPrefix(parentType.Name(), "__") {
+			resolveFn = MethodResolveFn
+		}
 	}
 
 	// Build a map of arguments from the field.arguments AST, using the
@@ -639,9 +741,19 @@ func resolveField(eCtx *executionContext, parentType *Object, source any, fieldA
 	// TODO: find a way to memoize, in case this field is within a List type.
 	args := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
 
+	if message := eCtx.introspectionPolicy.checkIntrospectionField(fieldDef, func() bool {
+		includeNonStandard, _ := args[argIncludeNonStandard].(bool)
+		return includeNonStandard
+	}); message != "" {
+		panic(gqlerrors.NewFormattedError(message))
+	}
+	if err := authorizeField(&eCtx.Schema, eCtx.Context, parentType, fieldDef, args, source); err != nil {
+		panic(NewLocatedErrorWithPath(err, FieldASTsToNodeASTs(field.fieldASTs), path.AsArray()))
+	}
+
 	info := ResolveInfo{
 		FieldName:      fieldName,
-		FieldASTs:      fieldASTs,
+		FieldASTs:      field.fieldASTs,
 		Path:           path,
 		ReturnType:     returnType,
 		ParentType:     parentType,
@@ -672,48 +784,53 @@ func resolveField(eCtx *executionContext, parentType *Object, source any, fieldA
 	}
 
 	if resolveFnError != nil {
-		panic(resolveFnError)
+		err := NewLocatedErrorWithPath(resolveFnError, FieldASTsToNodeASTs(field.fieldASTs), path.AsArray())
+		if _, ok := resolveFnError.(*NonFatalError); !ok {
+			panic(err)
+		}
+		// report the errors, but still complete the result
+		eCtx.Errors = append(eCtx.Errors, presentErrors(eCtx, err)...)
 	}
 
-	completed := completeValueCatchingError(eCtx, returnType, fieldASTs, info, path, result)
+	completed := completeValueCatchingError(eCtx, returnType, field, info, path, result)
 	return completed, resultState
 }
 
-func completeValueCatchingError(eCtx *executionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result any) (completed any) {
+func completeValueCatchingError(eCtx *executionContext, returnType Type, field *orderedField, info ResolveInfo, path *ResponsePath, result any) (completed any) {
 	// catch panic
 	defer func() any {
 		if r := recover(); r != nil {
-			handleFieldError(r, FieldASTsToNodeASTs(fieldASTs), path, returnType, eCtx)
+			handleFieldError(r, FieldASTsToNodeASTs(field.fieldASTs), path, returnType, eCtx)
 			return completed
 		}
 		return completed
 	}()
 
 	if returnType, ok := returnType.(*NonNull); ok {
-		completed := completeValue(eCtx, returnType, fieldASTs, info, path, result)
+		completed := completeValue(eCtx, returnType, field, info, path, result)
 		return completed
 	}
-	completed = completeValue(eCtx, returnType, fieldASTs, info, path, result)
+	completed = completeValue(eCtx, returnType, field, info, path, result)
 	return completed
 }
 
-func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result any) any {
+func completeValue(eCtx *executionContext, returnType Type, field *orderedField, info ResolveInfo, path *ResponsePath, result any) any {
 
 	resultVal := reflect.ValueOf(result)
 	if resultVal.IsValid() && resultVal.Kind() == reflect.Func {
 		return func() any {
-			return completeThunkValueCatchingError(eCtx, returnType, fieldASTs, info, path, result)
+			return completeThunkValueCatchingError(eCtx, returnType, field, info, path, result)
 		}
 	}
 
 	// If field type is NonNull, complete for inner type, and throw field error
 	// if result is null.
 	if returnType, ok := returnType.(*NonNull); ok {
-		completed := completeValue(eCtx, returnType.OfType, fieldASTs, info, path, result)
+		completed := completeValue(eCtx, returnType.OfType, field, info, path, result)
 		if completed == nil {
 			err := NewLocatedErrorWithPath(
-				fmt.Sprintf("Cannot return null for non-nullable field %v.%v.", info.ParentType, info.FieldName),
-				FieldASTsToNodeASTs(fieldASTs),
+				gqlerrors.NewFormattedError(fmt.Sprintf("Cannot return null for non-nullable field %v.%v.", info.ParentType, info.FieldName)),
+				FieldASTsToNodeASTs(field.fieldASTs),
 				path.AsArray(),
 			)
 			panic(gqlerrors.FormatError(err))
@@ -728,7 +845,7 @@ func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Fie
 
 	// If field type is List, complete each item in the list with the inner type
 	if returnType, ok := returnType.(*List); ok {
-		return completeListValue(eCtx, returnType, fieldASTs, info, path, result)
+		return completeListValue(eCtx, returnType, field, info, path, result)
 	}
 
 	// If field type is a leaf type, Scalar or Enum, serialize to a valid value,
@@ -743,15 +860,15 @@ func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Fie
 	// If field type is an abstract type, Interface or Union, determine the
 	// runtime Object type and complete for that type.
 	if returnType, ok := returnType.(*Union); ok {
-		return completeAbstractValue(eCtx, returnType, fieldASTs, info, path, result)
+		return completeAbstractValue(eCtx, returnType, field, info, path, result)
 	}
 	if returnType, ok := returnType.(*Interface); ok {
-		return completeAbstractValue(eCtx, returnType, fieldASTs, info, path, result)
+		return completeAbstractValue(eCtx, returnType, field, info, path, result)
 	}
 
 	// If field type is Object, execute and complete all sub-selections.
 	if returnType, ok := returnType.(*Object); ok {
-		return completeObjectValue(eCtx, returnType, fieldASTs, info, path, result)
+		return completeObjectValue(eCtx, returnType, field, info, path, result)
 	}
 
 	// Not reachable. All possible output types have been considered.
@@ -764,12 +881,12 @@ func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Fie
 	return nil
 }
 
-func completeThunkValueCatchingError(eCtx *executionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result any) (completed any) {
+func completeThunkValueCatchingError(eCtx *executionContext, returnType Type, field *orderedField, info ResolveInfo, path *ResponsePath, result any) (completed any) {
 
 	// catch any panic invoked from the propertyFn (thunk)
 	defer func() {
 		if r := recover(); r != nil {
-			handleFieldError(r, FieldASTsToNodeASTs(fieldASTs), path, returnType, eCtx)
+			handleFieldError(r, FieldASTsToNodeASTs(field.fieldASTs), path, returnType, eCtx)
 		}
 	}()
 
@@ -780,23 +897,28 @@ func completeThunkValueCatchingError(eCtx *executionContext, returnType Type, fi
 	}
 	fnResult, err := propertyFn()
 	if err != nil {
-		panic(gqlerrors.FormatError(err))
+		if _, ok := err.(*NonFatalError); !ok {
+			panic(gqlerrors.FormatError(err))
+		}
+		// report the errors, but still complete the result
+		located := NewLocatedErrorWithPath(err, FieldASTsToNodeASTs(field.fieldASTs), path.AsArray())
+		eCtx.Errors = append(eCtx.Errors, presentErrors(eCtx, located)...)
 	}
 
 	result = fnResult
 
 	if returnType, ok := returnType.(*NonNull); ok {
-		completed := completeValue(eCtx, returnType, fieldASTs, info, path, result)
+		completed := completeValue(eCtx, returnType, field, info, path, result)
 		return completed
 	}
-	completed = completeValue(eCtx, returnType, fieldASTs, info, path, result)
+	completed = completeValue(eCtx, returnType, field, info, path, result)
 
 	return completed
 }
 
 // completeAbstractValue completes value of an Abstract type (Union / Interface) by determining the runtime type
 // of that value, then completing based on that type.
-func completeAbstractValue(eCtx *executionContext, returnType Abstract, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result any) any {
+func completeAbstractValue(eCtx *executionContext, returnType Abstract, field *orderedField, info ResolveInfo, path *ResponsePath, result any) any {
 
 	var runtimeType *Object
 
@@ -814,7 +936,9 @@ func completeAbstractValue(eCtx *executionContext, returnType Abstract, fieldAST
 	}
 
 	err := invariantf(runtimeType != nil, `Abstract type %v must resolve to an Object type at runtime `+
-		`for field %v.%v with value "%v", received "%v".`, returnType, info.ParentType, info.FieldName, result, runtimeType,
+		`for field %v.%v with value "%v", received "%v". Either the %v type should provide a ResolveType `+
+		`function resolving the value, or a possible type should declare Go type %T in its GoTypes `+
+		`or accept it with IsTypeOf.`, returnType, info.ParentType, info.FieldName, result, runtimeType, returnType, result,
 	)
 	if err != nil {
 		panic(err)
@@ -827,11 +951,11 @@ func completeAbstractValue(eCtx *executionContext, returnType Abstract, fieldAST
 		))
 	}
 
-	return completeObjectValue(eCtx, runtimeType, fieldASTs, info, path, result)
+	return completeObjectValue(eCtx, runtimeType, field, info, path, result)
 }
 
 // completeObjectValue complete an Object value by executing all sub-selections.
-func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result any) any {
+func completeObjectValue(eCtx *executionContext, returnType *Object, field *orderedField, info ResolveInfo, path *ResponsePath, result any) any {
 
 	// If there is an isTypeOf predicate function, call it with the
 	// current result. If isTypeOf returns false, then raise an error rather
@@ -849,33 +973,41 @@ func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs [
 		}
 	}
 
+	if err := authorizeType(&eCtx.Schema, eCtx.Context, returnType, result); err != nil {
+		panic(NewLocatedErrorWithPath(err, FieldASTsToNodeASTs(field.fieldASTs), path.AsArray()))
+	}
+
 	// Collect sub-fields to execute to complete this value.
-	subFieldASTs := map[string][]*ast.Field{}
-	visitedFragmentNames := map[string]bool{}
-	for _, fieldAST := range fieldASTs {
-		if fieldAST == nil {
-			continue
-		}
-		selectionSet := fieldAST.SelectionSet
-		if selectionSet != nil {
-			innerParams := collectFieldsParams{
-				ExeContext:           eCtx,
-				RuntimeType:          returnType,
-				SelectionSet:         selectionSet,
-				Fields:               subFieldASTs,
-				VisitedFragmentNames: visitedFragmentNames,
+	plan := fieldPlan(eCtx, returnType, field, func(usesVariables *bool) map[string][]*ast.Field {
+		subFieldASTs := map[string][]*ast.Field{}
+		visitedFragmentNames := map[string]bool{}
+		for _, fieldAST := range field.fieldASTs {
+			if fieldAST == nil {
+				continue
+			}
+			selectionSet := fieldAST.SelectionSet
+			if selectionSet != nil {
+				innerParams := collectFieldsParams{
+					ExeContext:           eCtx,
+					RuntimeType:          returnType,
+					SelectionSet:         selectionSet,
+					Fields:               subFieldASTs,
+					VisitedFragmentNames: visitedFragmentNames,
+					UsesVariables:        usesVariables,
+				}
+				subFieldASTs = collectFields(innerParams)
 			}
-			subFieldASTs = collectFields(innerParams)
 		}
-	}
+		return subFieldASTs
+	})
 	executeFieldsParams := executeFieldsParams{
 		ExecutionContext: eCtx,
 		ParentType:       returnType,
 		Source:           result,
-		Fields:           subFieldASTs,
+		Plan:             plan,
 		Path:             path,
 	}
-	return executeSubFields(executeFieldsParams)
+	return newObject(eCtx, executeSubFields(executeFieldsParams), plan)
 }
 
 // completeLeafValue complete a leaf value (Scalar / Enum) by serializing to a valid value, returning nil if serialization is not possible.
@@ -888,7 +1020,7 @@ func completeLeafValue(returnType Leaf, result any) any {
 }
 
 // completeListValue complete a list value by completing each item in the list with the inner type
-func completeListValue(eCtx *executionContext, returnType *List, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result any) any {
+func completeListValue(eCtx *executionContext, returnType *List, field *orderedField, info ResolveInfo, path *ResponsePath, result any) any {
 	resultVal := reflect.ValueOf(result)
 	if resultVal.Kind() == reflect.Ptr {
 		resultVal = resultVal.Elem()
@@ -911,16 +1043,20 @@ func completeListValue(eCtx *executionContext, returnType *List, fieldASTs []*as
 	for i := 0; i < resultVal.Len(); i++ {
 		val := resultVal.Index(i).Interface()
 		fieldPath := path.WithKey(i)
-		completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, info, fieldPath, val)
+		completedItem := completeValueCatchingError(eCtx, itemType, field, info, fieldPath, val)
 		completedResults = append(completedResults, completedItem)
 	}
 	return completedResults
 }
 
 // defaultResolveTypeFn If a resolveType function is not given, then a default resolve behavior is
-// used which tests each possible type for the abstract type by calling
+// used which looks up the possible type declaring the Go type of the object being
+// coerced, or otherwise tests each possible type for the abstract type by calling
 // isTypeOf for the object being coerced, returning the first type that matches.
 func defaultResolveTypeFn(p ResolveTypeParams, abstractType Abstract) *Object {
+	if runtimeType := p.Info.Schema.objectForGoType(abstractType, reflect.TypeOf(p.Value)); runtimeType != nil {
+		return runtimeType
+	}
 	possibleTypes := p.Info.Schema.PossibleTypes(abstractType)
 	for _, possibleType := range possibleTypes {
 		if possibleType.IsTypeOf == nil {
@@ -944,49 +1080,35 @@ type FieldResolver interface {
 // which takes the property of the source object of the same name as the field
 // and returns it as the result, or if it's a function, returns the result
 // of calling that function.
+//
+// For struct sources the property is the first field whose name matches the
+// field name case-insensitively or whose `json` or `graphql` tag names it,
+// including fields promoted from embedded structs. How a field is resolved is
+// computed once per source type and field name and cached.
 func DefaultResolveFn(p ResolveParams) (any, error) {
-	sourceVal := reflect.ValueOf(p.Source)
-	// Check if value implements 'Resolver' interface
-	if resolver, ok := sourceVal.Interface().(FieldResolver); ok {
-		return resolver.Resolve(p)
-	}
+	return defaultResolve(p, false)
+}
 
-	// try to resolve p.Source as a struct
-	if sourceVal.IsValid() && sourceVal.Type().Kind() == reflect.Ptr {
-		sourceVal = sourceVal.Elem()
-	}
-	if !sourceVal.IsValid() {
-		return nil, nil
-	}
+// MethodResolveFn resolves like DefaultResolveFn, but for sources without a
+// matching struct field it calls an exported method named like the field, or
+// like the field prefixed with "Get". The method may take no arguments, a
+// ResolveParams or a context.Context, and may return an error as its second
+// result.
+//
+// It is used instead of DefaultResolveFn for the fields of schemas created
+// with SchemaConfig.ResolveMethods, except those of introspection types.
+func MethodResolveFn(p ResolveParams) (any, error) {
+	return defaultResolve(p, true)
+}
 
-	if sourceVal.Type().Kind() == reflect.Struct {
-		for i := 0; i < sourceVal.NumField(); i++ {
-			valueField := sourceVal.Field(i)
-			typeField := sourceVal.Type().Field(i)
-			// try matching the field name first
-			if strings.EqualFold(typeField.Name, p.Info.FieldName) {
-				return valueField.Interface(), nil
-			}
-			tag := typeField.Tag
-			checkTag := func(tagName string) bool {
-				t := tag.Get(tagName)
-				tOptions := strings.Split(t, ",")
-				if len(tOptions) == 0 {
-					return false
-				}
-				if tOptions[0] != p.Info.FieldName {
-					return false
-				}
-				return true
-			}
-			if checkTag("json") || checkTag("graphql") {
-				return valueField.Interface(), nil
-			} else {
-				continue
-			}
-		}
+func defaultResolve(p ResolveParams, methods bool) (any, error) {
+	if p.Source == nil {
 		return nil, nil
 	}
+	// Check if value implements 'Resolver' interface
+	if resolver, ok := p.Source.(FieldResolver); ok {
+		return resolver.Resolve(p)
+	}
 
 	// try p.Source as a map[string]interface
 	if sourceMap, ok := p.Source.(map[string]any); ok {
@@ -1002,9 +1124,14 @@ func DefaultResolveFn(p ResolveParams) (any, error) {
 		return property, nil
 	}
 
+	sourceVal := reflect.ValueOf(p.Source)
+	if sourceVal.Kind() == reflect.Ptr && sourceVal.IsNil() {
+		return nil, nil
+	}
+
 	// Try accessing as map via reflection
-	if r := reflect.ValueOf(p.Source); r.Kind() == reflect.Map && r.Type().Key().Kind() == reflect.String {
-		val := r.MapIndex(reflect.ValueOf(p.Info.FieldName))
+	if sourceVal.Kind() == reflect.Map && sourceVal.Type().Key().Kind() == reflect.String {
+		val := sourceVal.MapIndex(reflect.ValueOf(p.Info.FieldName).Convert(sourceVal.Type().Key()))
 		if val.IsValid() {
 			property := val.Interface()
 			if val.Type().Kind() == reflect.Func {
@@ -1018,8 +1145,8 @@ func DefaultResolveFn(p ResolveParams) (any, error) {
 		}
 	}
 
-	// last resort, return nil
-	return nil, nil
+	// try p.Source as a struct, or a value with a getter method
+	return defaultResolvePlanFor(sourceVal.Type(), p.Info.FieldName, methods).resolve(sourceVal, p)
 }
 
 // This method looks up the field on the given type definition.
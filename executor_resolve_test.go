@@ -1,11 +1,15 @@
 package graphql_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/location"
 	"github.com/machship/graphql/testutil"
 )
 
@@ -265,3 +269,238 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
 	}
 }
+
+type resolveTestBase struct {
+	ID string `json:"id"`
+}
+
+type resolveTestAudit struct {
+	CreatedBy string
+}
+
+type resolveTestUser struct {
+	resolveTestBase
+	*resolveTestAudit
+	first string
+	last  string
+}
+
+func (u resolveTestUser) Name() string {
+	return u.first + " " + u.last
+}
+
+func (u *resolveTestUser) GetInitials() (string, error) {
+	if u.first == "" || u.last == "" {
+		return "", errors.New("missing name")
+	}
+	return u.first[:1] + u.last[:1], nil
+}
+
+func (u *resolveTestUser) Greeting(p graphql.ResolveParams) string {
+	return "Hello from " + p.Info.FieldName
+}
+
+type resolveTestCtxKey struct{}
+
+func (u *resolveTestUser) Viewer(ctx context.Context) (string, error) {
+	viewer, _ := ctx.Value(resolveTestCtxKey{}).(string)
+	return viewer, nil
+}
+
+func resolveTestUserSchema(t *testing.T, resolveMethods bool) graphql.Schema {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.String},
+			"createdBy": &graphql.Field{Type: graphql.String},
+			"name":      &graphql.Field{Type: graphql.String},
+			"initials":  &graphql.Field{Type: graphql.String},
+			"greeting":  &graphql.Field{Type: graphql.String},
+			"viewer":    &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type: graphql.NewList(userType),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return []*resolveTestUser{
+							{
+								resolveTestBase:  resolveTestBase{ID: "1"},
+								resolveTestAudit: &resolveTestAudit{CreatedBy: "admin"},
+								first:            "Ada",
+								last:             "Lovelace",
+							},
+							{
+								resolveTestBase: resolveTestBase{ID: "2"},
+								first:           "Plato",
+							},
+						}, nil
+					},
+				},
+			},
+		}),
+		ResolveMethods: resolveMethods,
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	return schema
+}
+
+func TestExecutesResolveFunction_DefaultFunctionResolvesEmbeddedFields(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"users": []any{
+				map[string]any{
+					"id":        "1",
+					"createdBy": "admin",
+					"name":      nil,
+					"initials":  nil,
+					"greeting":  nil,
+					"viewer":    nil,
+				},
+				map[string]any{
+					"id":        "2",
+					"createdBy": nil,
+					"name":      nil,
+					"initials":  nil,
+					"greeting":  nil,
+					"viewer":    nil,
+				},
+			},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        resolveTestUserSchema(t, false),
+		RequestString: `{ users { id createdBy name initials greeting viewer } }`,
+		Context:       context.WithValue(context.Background(), resolveTestCtxKey{}, "root"),
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesResolveFunction_ResolveMethodsCallsGetterMethods(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"users": []any{
+				map[string]any{
+					"id":        "1",
+					"createdBy": "admin",
+					"name":      "Ada Lovelace",
+					"initials":  "AL",
+					"greeting":  "Hello from greeting",
+					"viewer":    "root",
+				},
+				map[string]any{
+					"id":        "2",
+					"createdBy": nil,
+					"name":      "Plato ",
+					"initials":  nil,
+					"greeting":  "Hello from greeting",
+					"viewer":    "root",
+				},
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "missing name",
+				Locations: []location.SourceLocation{{Line: 1, Column: 29}},
+				Path:      []any{"users", 1, "initials"},
+			},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        resolveTestUserSchema(t, true),
+		RequestString: `{ users { id createdBy name initials greeting viewer } }`,
+		Context:       context.WithValue(context.Background(), resolveTestCtxKey{}, "root"),
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+type resolveTestLabel struct {
+	Label string `json:"name"`
+	Title string
+}
+
+func (l resolveTestLabel) Name() string {
+	return "method"
+}
+
+func (l resolveTestLabel) GetTitle() string {
+	return "method"
+}
+
+func TestExecutesResolveFunction_ResolveMethodsPrefersStructFieldsOverMethods(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"test": &graphql.Field{
+					Type: graphql.NewObject(graphql.ObjectConfig{
+						Name: "Label",
+						Fields: graphql.Fields{
+							"name":  &graphql.Field{Type: graphql.String},
+							"title": &graphql.Field{Type: graphql.String},
+						},
+					}),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return resolveTestLabel{Label: "tagged field", Title: "named field"}, nil
+					},
+				},
+			},
+		}),
+		ResolveMethods: true,
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"test": map[string]any{
+				"name":  "tagged field",
+				"title": "named field",
+			},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test { name title } }`,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesResolveFunction_ResolveMethodsKeepsIntrospectionOfWrappingTypes(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"__type": map[string]any{
+				"fields": []any{
+					map[string]any{
+						"type": map[string]any{
+							"name":        nil,
+							"description": nil,
+							"ofType": map[string]any{
+								"name": "User",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        resolveTestUserSchema(t, true),
+		RequestString: `{ __type(name: "Query") { fields { type { name description ofType { name } } } } }`,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -2414,3 +2415,357 @@ func TestQuery_OriginalErrorPanic(t *testing.T) {
 		t.Fatalf("unexpected error: %v", reflect.TypeOf(err))
 	}
 }
+
+func TestMaskingErrorPresenter_MasksInternalErrors(t *testing.T) {
+	type report struct {
+		message       string
+		path          []any
+		correlationID string
+	}
+	var reports []report
+	presenter := graphql.MaskingErrorPresenter(func(p graphql.PresentErrorParams, correlationID string) {
+		reports = append(reports, report{p.OriginalError.Error(), p.Path, correlationID})
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"database": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return nil, errors.New("pq: password authentication failed")
+					},
+				},
+				"panics": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						panic("index out of range")
+					},
+				},
+				"extended": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return nil, extendedError{
+							error:      errors.New("Not found"),
+							extensions: map[string]any{"code": "NOT_FOUND"},
+						}
+					},
+				},
+				"nonNull": &graphql.Field{
+					Type: graphql.NewObject(graphql.ObjectConfig{
+						Name: "Wrapper",
+						Fields: graphql.Fields{
+							"value": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+						},
+					}),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return map[string]any{}, nil
+					},
+				},
+				"notIterable": &graphql.Field{
+					Type: graphql.NewList(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return "not a list", nil
+					},
+				},
+			},
+		}),
+		ErrorPresenter: presenter,
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ database panics extended nonNull { value } notIterable }`,
+	})
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reported errors, got %v", reports)
+	}
+	expectedReports := []report{
+		{"pq: password authentication failed", []any{"database"}, reports[0].correlationID},
+		{"index out of range", []any{"panics"}, reports[1].correlationID},
+	}
+	if !reflect.DeepEqual(expectedReports, reports) {
+		t.Fatalf("Unexpected reports, Diff: %v", testutil.Diff(expectedReports, reports))
+	}
+	if reports[0].correlationID == "" || reports[0].correlationID == reports[1].correlationID {
+		t.Fatalf("expected distinct correlation IDs, got %v", reports)
+	}
+
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"database":    nil,
+			"panics":      nil,
+			"extended":    nil,
+			"nonNull":     nil,
+			"notIterable": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:    graphql.MaskedErrorMessage,
+				Locations:  []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:       []any{"database"},
+				Extensions: map[string]any{"correlationId": reports[0].correlationID},
+			},
+			{
+				Message:    graphql.MaskedErrorMessage,
+				Locations:  []location.SourceLocation{{Line: 1, Column: 12}},
+				Path:       []any{"panics"},
+				Extensions: map[string]any{"correlationId": reports[1].correlationID},
+			},
+			{
+				Message:    "Not found",
+				Locations:  []location.SourceLocation{{Line: 1, Column: 19}},
+				Path:       []any{"extended"},
+				Extensions: map[string]any{"code": "NOT_FOUND"},
+			},
+			{
+				Message:   "Cannot return null for non-nullable field Wrapper.value.",
+				Locations: []location.SourceLocation{{Line: 1, Column: 38}},
+				Path:      []any{"nonNull", "value"},
+			},
+			{
+				Message:   "User Error: expected iterable, but did not find one for field Query.notIterable.",
+				Locations: []location.SourceLocation{{Line: 1, Column: 46}},
+				Path:      []any{"notIterable"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestErrorPresenter_RequestPresenterOverridesSchemaPresenter(t *testing.T) {
+	type ctxKey struct{}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"database": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return nil, errors.New("pq: password authentication failed")
+					},
+				},
+			},
+		}),
+		ErrorPresenter: graphql.MaskingErrorPresenter(nil),
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	var params graphql.PresentErrorParams
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ database }`,
+		Context:       context.WithValue(context.Background(), ctxKey{}, "request"),
+		ErrorPresenter: func(p graphql.PresentErrorParams) gqlerrors.FormattedError {
+			params = p
+			presented := p.Error
+			presented.Message = "Database unavailable"
+			presented.Extensions = map[string]any{"code": "UNAVAILABLE"}
+			return presented
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"database": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:    "Database unavailable",
+				Locations:  []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:       []any{"database"},
+				Extensions: map[string]any{"code": "UNAVAILABLE"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if params.OriginalError == nil || params.OriginalError.Error() != "pq: password authentication failed" {
+		t.Fatalf("expected the original error to be passed to the presenter, got %v", params.OriginalError)
+	}
+	if params.Context.Value(ctxKey{}) != "request" {
+		t.Fatalf("expected the request context to be passed to the presenter")
+	}
+	if params.Error.Message != "pq: password authentication failed" {
+		t.Fatalf("expected the default formatted error to be passed to the presenter, got %v", params.Error.Message)
+	}
+}
+
+type recoverTestPanic struct {
+	Code int
+}
+
+func TestPanicWithNonErrorValueIsReportedAsFieldError(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: testSchema(t, &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				panic(recoverTestPanic{Code: 7})
+			},
+		}),
+		RequestString: `{ test }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"test": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "{7}",
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []any{"test"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	located, ok := result.Errors[0].OriginalError().(*gqlerrors.Error)
+	if !ok {
+		t.Fatalf("Expected a located error, got %T", result.Errors[0].OriginalError())
+	}
+	if !strings.Contains(located.Stack, "executor_test.go") {
+		t.Fatalf("Expected the stack of the panic to be captured, got %q", located.Stack)
+	}
+}
+
+func TestPanicStackTraceIsExposedInDebugMode(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: testSchema(t, &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				panic(recoverTestPanic{Code: 7})
+			},
+		}),
+		RequestString: `{ test }`,
+		Debug:         true,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	stacktrace, ok := result.Errors[0].Extensions["stacktrace"].([]string)
+	if !ok {
+		t.Fatalf("Expected a stacktrace extension, got %v", result.Errors[0].Extensions)
+	}
+	if !strings.HasPrefix(stacktrace[0], "goroutine ") || !strings.Contains(strings.Join(stacktrace, "\n"), "executor_test.go") {
+		t.Fatalf("Expected the stack of the panic, got %v", stacktrace)
+	}
+}
+
+func TestReturnedErrorsHaveNoStackTraceInDebugMode(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        tinit(t),
+		RequestString: `{ erred }`,
+		Debug:         true,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if result.Errors[0].Extensions != nil {
+		t.Fatalf("Expected no extensions, got %v", result.Errors[0].Extensions)
+	}
+}
+
+type multiErrorTestItem struct {
+	SKU string `json:"sku"`
+}
+
+func TestNonFatalErrorsKeepPartialData(t *testing.T) {
+	itemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"sku": &graphql.Field{Type: graphql.String},
+		},
+	})
+	fetchItems := func() (any, error) {
+		items := []*multiErrorTestItem{{SKU: "a"}, nil, {SKU: "c"}, nil}
+		return items, graphql.NonFatal(
+			&graphql.PathError{Path: []any{1}, Err: errors.New("item 1 not found")},
+			&graphql.PathError{Path: []any{3}, Err: errors.New("item 3 not found")},
+		)
+	}
+	resolvers := map[string]graphql.FieldResolveFn{
+		"eager": func(p graphql.ResolveParams) (any, error) {
+			return fetchItems()
+		},
+		"thunk": func(p graphql.ResolveParams) (any, error) {
+			return fetchItems, nil
+		},
+	}
+	for name, resolve := range resolvers {
+		result := graphql.Do(graphql.Params{
+			Schema: testSchema(t, &graphql.Field{
+				Type:    graphql.NewList(itemType),
+				Resolve: resolve,
+			}),
+			RequestString: `{ test { sku } }`,
+		})
+		expected := &graphql.Result{
+			Data: map[string]any{
+				"test": []any{
+					map[string]any{"sku": "a"},
+					nil,
+					map[string]any{"sku": "c"},
+					nil,
+				},
+			},
+			Errors: []gqlerrors.FormattedError{
+				{
+					Message:   "item 1 not found",
+					Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+					Path:      []any{"test", 1},
+				},
+				{
+					Message:   "item 3 not found",
+					Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+					Path:      []any{"test", 3},
+				},
+			},
+		}
+		if !testutil.EqualResults(expected, result) {
+			t.Fatalf("%v: Unexpected result, Diff: %v", name, testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestJoinedErrorsAreReportedSeparately(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: testSchema(t, &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return nil, errors.Join(errors.New("first"), graphql.MultiError{errors.New("second"), errors.New("third")})
+			},
+		}),
+		RequestString: `{ test }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"test": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "first",
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []any{"test"},
+			},
+			{
+				Message:   "second",
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []any{"test"},
+			},
+			{
+				Message:   "third",
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []any{"test"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -2,7 +2,6 @@ package graphql
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/machship/graphql/gqlerrors"
 )
@@ -64,7 +63,7 @@ func handleExtensionsInits(p *Params) gqlerrors.FormattedErrors {
 			// catch panic from an extension init fn
 			defer func() {
 				if r := recover(); r != nil {
-					errs = append(errs, gqlerrors.FormatError(fmt.Errorf("%s.Init: %v", ext.Name(), r.(error))))
+					errs = append(errs, extensionPanicError(ext.Name()+".Init", r, p.Debug))
 				}
 			}()
 			// update context
@@ -87,7 +86,7 @@ func handleExtensionsParseDidStart(p *Params) ([]gqlerrors.FormattedError, parse
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					errs = append(errs, gqlerrors.FormatError(fmt.Errorf("%s.ParseDidStart: %v", ext.Name(), r.(error))))
+					errs = append(errs, extensionPanicError(ext.Name()+".ParseDidStart", r, p.Debug))
 				}
 			}()
 			ctx, finishFn = ext.ParseDidStart(p.Context)
@@ -103,7 +102,7 @@ func handleExtensionsParseDidStart(p *Params) ([]gqlerrors.FormattedError, parse
 				// catch panic from a finishFn
 				defer func() {
 					if r := recover(); r != nil {
-						errs = append(errs, gqlerrors.FormatError(fmt.Errorf("%s.ParseFinishFunc: %v", name, r.(error))))
+						errs = append(errs, extensionPanicError(name+".ParseFinishFunc", r, p.Debug))
 					}
 				}()
 				fn(err)
@@ -126,7 +125,7 @@ func handleExtensionsValidationDidStart(p *Params) ([]gqlerrors.FormattedError,
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					errs = append(errs, gqlerrors.FormatError(fmt.Errorf("%s.ValidationDidStart: %v", ext.Name(), r.(error))))
+					errs = append(errs, extensionPanicError(ext.Name()+".ValidationDidStart", r, p.Debug))
 				}
 			}()
 			ctx, finishFn = ext.ValidationDidStart(p.Context)
@@ -142,7 +141,7 @@ func handleExtensionsValidationDidStart(p *Params) ([]gqlerrors.FormattedError,
 				// catch panic from a finishFn
 				defer func() {
 					if r := recover(); r != nil {
-						extErrs = append(extErrs, gqlerrors.FormatError(fmt.Errorf("%s.ValidationFinishFunc: %v", name, r.(error))))
+						extErrs = append(extErrs, extensionPanicError(name+".ValidationFinishFunc", r, p.Debug))
 					}
 				}()
 				finishFn(errs)
@@ -165,7 +164,7 @@ func handleExtensionsExecutionDidStart(p *ExecuteParams) ([]gqlerrors.FormattedE
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					errs = append(errs, gqlerrors.FormatError(fmt.Errorf("%s.ExecutionDidStart: %v", ext.Name(), r.(error))))
+					errs = append(errs, extensionPanicError(ext.Name()+".ExecutionDidStart", r, p.Debug))
 				}
 			}()
 			ctx, finishFn = ext.ExecutionDidStart(p.Context)
@@ -181,7 +180,7 @@ func handleExtensionsExecutionDidStart(p *ExecuteParams) ([]gqlerrors.FormattedE
 				// catch panic from a finishFn
 				defer func() {
 					if r := recover(); r != nil {
-						extErrs = append(extErrs, gqlerrors.FormatError(fmt.Errorf("%s.ExecutionFinishFunc: %v", name, r.(error))))
+						extErrs = append(extErrs, extensionPanicError(name+".ExecutionFinishFunc", r, p.Debug))
 					}
 				}()
 				finishFn(result)
@@ -204,7 +203,7 @@ func handleExtensionsResolveFieldDidStart(_ []Extension, p *executionContext, i
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					errs = append(errs, gqlerrors.FormatError(fmt.Errorf("%s.ResolveFieldDidStart: %v", ext.Name(), r.(error))))
+					errs = append(errs, extensionPanicError(ext.Name()+".ResolveFieldDidStart", r, p.Debug))
 				}
 			}()
 			ctx, finishFn = ext.ResolveFieldDidStart(p.Context, i)
@@ -220,7 +219,7 @@ func handleExtensionsResolveFieldDidStart(_ []Extension, p *executionContext, i
 				// catch panic from a finishFn
 				defer func() {
 					if r := recover(); r != nil {
-						extErrs = append(extErrs, gqlerrors.FormatError(fmt.Errorf("%s.ResolveFieldFinishFunc: %v", name, r.(error))))
+						extErrs = append(extErrs, extensionPanicError(name+".ResolveFieldFinishFunc", r, p.Debug))
 					}
 				}()
 				finishFn(val, err)
@@ -236,7 +235,7 @@ func addExtensionResults(p *ExecuteParams, result *Result) {
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						result.Errors = append(result.Errors, gqlerrors.FormatError(fmt.Errorf("%s.GetResult: %v", ext.Name(), r.(error))))
+						result.Errors = append(result.Errors, extensionPanicError(ext.Name()+".GetResult", r, p.Debug))
 					}
 				}()
 				if ext.HasResult() {
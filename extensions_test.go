@@ -67,6 +67,36 @@ func TestExtensionInitPanic(t *testing.T) {
 	}
 }
 
+func TestExtensionInitPanicWithNonErrorValue(t *testing.T) {
+	ext := newtestExt("testExt")
+	ext.initFn = func(ctx context.Context, p *graphql.Params) context.Context {
+		if true {
+			panic(42)
+		}
+		return ctx
+	}
+
+	schema := tinit(t)
+	query := `query Example { a }`
+	schema.AddExtensions(ext)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		Debug:         true,
+	})
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if expected := "testExt.Init: 42"; result.Errors[0].Message != expected {
+		t.Fatalf("Expected error %q, got %q", expected, result.Errors[0].Message)
+	}
+	if _, ok := result.Errors[0].Extensions["stacktrace"].([]string); !ok {
+		t.Fatalf("Expected a stacktrace extension, got %v", result.Errors[0].Extensions)
+	}
+}
+
 func TestExtensionParseDidStartPanic(t *testing.T) {
 	ext := newtestExt("testExt")
 	ext.parseDidStartFn = func(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
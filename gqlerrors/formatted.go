@@ -1,8 +1,6 @@
 package gqlerrors
 
 import (
-	"errors"
-
 	"github.com/machship/graphql/language/location"
 )
 
@@ -27,9 +25,13 @@ func (g FormattedError) Error() string {
 	return g.Message
 }
 
+// NewFormattedError returns a FormattedError with the given message. As it is
+// not created from another error, its OriginalError is nil.
 func NewFormattedError(message string) FormattedError {
-	err := errors.New(message)
-	return FormatError(err)
+	return FormattedError{
+		Message:   message,
+		Locations: []location.SourceLocation{},
+	}
 }
 
 func FormatError(err error) FormattedError {
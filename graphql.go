@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/ast"
 	"github.com/machship/graphql/language/parser"
 	"github.com/machship/graphql/language/source"
 )
@@ -31,19 +32,102 @@ type Params struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// ErrorPresenter, if set, is used instead of the ErrorPresenter of the
+	// schema to present the errors raised while executing fields.
+	ErrorPresenter ErrorPresenterFn
+
+	// Debug exposes the stack traces of recovered panics in the "stacktrace"
+	// extension of the corresponding errors. It should not be enabled in
+	// production.
+	Debug bool
+
+	// DocumentCache, if set, is consulted for the parsed document and the
+	// validation result of RequestString before parsing and validating it,
+	// e.g. a shared NewLRUDocumentCache(1000).
+	DocumentCache DocumentCache
+
+	// Extensions are the "extensions" of the request. The "persistedQuery"
+	// extension of automatic persisted queries is handled by Do: a request
+	// carrying only the sha256Hash of a query is served from
+	// PersistedQueries, and a request carrying both the hash and the query
+	// registers the query there once validated.
+	Extensions map[string]any
+
+	// PersistedQueries stores the queries of automatic persisted queries,
+	// e.g. a shared NewMemoryPersistedQueryStore(1000).
+	PersistedQueries PersistedQueryStore
+
+	// DocumentID refers to a document of TrustedDocuments to execute, in
+	// place of RequestString.
+	DocumentID string
+
+	// TrustedDocuments, if set, serves the documents referred to by
+	// DocumentID. In strict mode it rejects requests without a DocumentID.
+	TrustedDocuments *TrustedDocuments
+
+	// MutationHooks, if set, are used instead of the MutationHooks of the
+	// schema around the root fields of mutations, e.g. to run the mutation
+	// in a transaction.
+	MutationHooks *MutationHooks
+
+	// IntrospectionPolicy, if set, is used instead of the
+	// IntrospectionPolicy of the schema to restrict the introspection of
+	// the request.
+	IntrospectionPolicy IntrospectionPolicyFn
+
+	// ParseOptions limit the resources spent parsing RequestString, e.g.
+	// its MaxTokens, MaxDepth and MaxLength. Documents served from the
+	// DocumentCache were parsed with the options of the request that added
+	// them.
+	ParseOptions parser.ParseOptions
+
+	// ValidationRules are run after the SpecifiedRules for every request of
+	// Do and Subscribe, with access to VariableValues, e.g. a CostRule. Their
+	// results are not cached.
+	ValidationRules []ValidationRuleFn
+
+	// OrderedResult completes the objects of Data as *OrderedObject rather
+	// than map[string]any, so that marshalling the Result writes their fields
+	// in query order rather than sorted.
+	OrderedResult bool
 }
 
 func Do(p Params) *Result {
+	return do(p, true)
+}
+
+// do executes a request, running the Init of the extensions first if
+// runInits is set.
+func do(p Params, runInits bool) *Result {
+	cached, err := resolveTrustedDocument(&p)
+	if err != nil {
+		return &Result{
+			Errors: []gqlerrors.FormattedError{formatRequestError(err)},
+		}
+	}
+
+	var registerPersistedQuery func()
+	if p.DocumentID == "" {
+		registerPersistedQuery, err = resolvePersistedQuery(&p)
+		if err != nil {
+			return &Result{
+				Errors: []gqlerrors.FormattedError{formatRequestError(err)},
+			}
+		}
+	}
+
 	source := source.NewSource(&source.Source{
 		Body: []byte(p.RequestString),
 		Name: "GraphQL request",
 	})
 
 	// run init on the extensions
-	extErrs := handleExtensionsInits(&p)
-	if len(extErrs) != 0 {
-		return &Result{
-			Errors: extErrs,
+	if runInits {
+		if extErrs := handleExtensionsInits(&p); len(extErrs) != 0 {
+			return &Result{
+				Errors: extErrs,
+			}
 		}
 	}
 
@@ -54,8 +138,21 @@ func Do(p Params) *Result {
 		}
 	}
 
+	var (
+		cacheKey DocumentCacheKey
+		AST      *ast.Document
+	)
+	if cached == nil && p.DocumentCache != nil {
+		cacheKey = newDocumentCacheKey(&p.Schema, p.RequestString)
+		cached, _ = p.DocumentCache.Get(cacheKey)
+	}
+
 	// parse the source
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	if cached != nil {
+		AST = cached.Document
+	} else {
+		AST, err = parser.Parse(parser.ParseParams{Source: source, Options: p.ParseOptions})
+	}
 	if err != nil {
 		// run parseFinishFuncs for extensions
 		extErrs = parseFinishFn(err)
@@ -84,7 +181,20 @@ func Do(p Params) *Result {
 	}
 
 	// validate document
-	validationResult := ValidateDocument(&p.Schema, AST, nil)
+	var validationResult ValidationResult
+	if cached != nil {
+		validationResult = cached.Validation
+	} else {
+		validationResult = ValidateDocument(&p.Schema, AST, nil)
+		if p.DocumentCache != nil {
+			p.DocumentCache.Add(cacheKey, &CachedDocument{
+				Document:   AST,
+				Validation: validationResult,
+			})
+		}
+	}
+
+	validationResult = validateRequest(&p, AST, validationResult)
 
 	if !validationResult.IsValid {
 		// run validation finish functions for extensions
@@ -93,7 +203,8 @@ func Do(p Params) *Result {
 		// merge the errors from extensions and the original error from parser
 		extErrs = append(extErrs, validationResult.Errors...)
 		return &Result{
-			Errors: extErrs,
+			Errors:     extErrs,
+			Extensions: validationResult.Extensions,
 		}
 	}
 
@@ -105,12 +216,45 @@ func Do(p Params) *Result {
 		}
 	}
 
-	return Execute(ExecuteParams{
-		Schema:        p.Schema,
-		Root:          p.RootObject,
-		AST:           AST,
-		OperationName: p.OperationName,
-		Args:          p.VariableValues,
-		Context:       p.Context,
+	if registerPersistedQuery != nil {
+		registerPersistedQuery()
+	}
+
+	result := Execute(ExecuteParams{
+		Schema:              p.Schema,
+		Root:                p.RootObject,
+		AST:                 AST,
+		OperationName:       p.OperationName,
+		Args:                p.VariableValues,
+		Context:             p.Context,
+		ErrorPresenter:      p.ErrorPresenter,
+		Debug:               p.Debug,
+		MutationHooks:       p.MutationHooks,
+		OrderedResult:       p.OrderedResult,
+		IntrospectionPolicy: p.IntrospectionPolicy,
 	})
+	for key, value := range validationResult.Extensions {
+		if result.Extensions == nil {
+			result.Extensions = map[string]any{}
+		}
+		result.Extensions[key] = value
+	}
+	return result
+}
+
+// validateRequest runs the validation rules of a request on a document which
+// passed the SpecifiedRules: Params.ValidationRules and the rules enforcing the
+// introspection and authorization policies of the request.
+func validateRequest(p *Params, AST *ast.Document, validationResult ValidationResult) ValidationResult {
+	rules := p.ValidationRules
+	if policy := introspectionPolicy(&p.Schema, p.IntrospectionPolicy, p.Context); policy.restricts() {
+		rules = append(rules[:len(rules):len(rules)], IntrospectionPolicyRule(policy))
+	}
+	if p.Schema.authorizationFailure == AuthorizationFailureOperation {
+		rules = append(rules[:len(rules):len(rules)], AuthorizationRule(p.Context))
+	}
+	if validationResult.IsValid && len(rules) != 0 {
+		validationResult = validateDocument(&p.Schema, AST, rules, p.VariableValues)
+	}
+	return validationResult
 }
@@ -1,6 +1,7 @@
 package graphql_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/machship/graphql"
@@ -60,6 +61,72 @@ func nItemsListQueryBenchmark(x int) func(b *testing.B) {
 	}
 }
 
+// Benchmark a reasonably large list of small items resolved by DefaultResolveFn.
+func BenchmarkListQueryDefaultResolve_1(b *testing.B) {
+	nItemsListQueryDefaultResolveBenchmark(1)(b)
+}
+
+func BenchmarkListQueryDefaultResolve_100(b *testing.B) {
+	nItemsListQueryDefaultResolveBenchmark(100)(b)
+}
+
+func BenchmarkListQueryDefaultResolve_1K(b *testing.B) {
+	nItemsListQueryDefaultResolveBenchmark(1000)(b)
+}
+
+func BenchmarkListQueryDefaultResolve_10K(b *testing.B) {
+	nItemsListQueryDefaultResolveBenchmark(10 * 1000)(b)
+}
+
+func nItemsListQueryDefaultResolveBenchmark(x int) func(b *testing.B) {
+	return func(b *testing.B) {
+		schema := benchutil.ListSchemaWithXItemsDefaultResolve(x)
+		query := `
+			query {
+				colors {
+					hex
+					r
+					g
+					b
+				}
+			}
+		`
+
+		for i := 0; i < b.N; i++ {
+
+			params := graphql.Params{
+				Schema:        schema,
+				RequestString: query,
+			}
+			benchGraphql(params, b)
+		}
+	}
+}
+
+type benchResolveSource struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `graphql:"createdAt"`
+	UpdatedAt   string `graphql:"updatedAt"`
+	Owner       string `json:"owner,omitempty"`
+	Status      string `json:"status"`
+	Weight      float64
+}
+
+// Benchmark DefaultResolveFn resolving the last field of a struct.
+func BenchmarkDefaultResolveFn_StructField(b *testing.B) {
+	p := graphql.ResolveParams{
+		Source: &benchResolveSource{Weight: 1.5},
+		Info:   graphql.ResolveInfo{FieldName: "weight"},
+	}
+	for i := 0; i < b.N; i++ {
+		if _, err := graphql.DefaultResolveFn(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkWideQuery_1_1(b *testing.B) {
 	nFieldsyItemsQueryBenchmark(1, 1)(b)
 }
@@ -108,3 +175,43 @@ func nFieldsyItemsQueryBenchmark(x int, y int) func(b *testing.B) {
 		}
 	}
 }
+
+// Benchmark executing a prepared operation, which is parsed, validated and
+// planned once.
+func BenchmarkPreparedWideQuery_10_10(b *testing.B) {
+	schema := benchutil.WideSchemaWithXFieldsAndYItems(10, 10)
+	op, err := graphql.Prepare(schema, benchutil.WideSchemaQuery(10), "")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result := op.Execute(context.Background(), nil, nil)
+		if len(result.Errors) > 0 {
+			b.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+	}
+}
+
+// Benchmark Do with a document cache, which parses and validates the query
+// once.
+func BenchmarkWideQueryDocumentCache_10_10(b *testing.B) {
+	schema := benchutil.WideSchemaWithXFieldsAndYItems(10, 10)
+	query := benchutil.WideSchemaQuery(10)
+	cache := graphql.NewLRUDocumentCache(100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		params := graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+			DocumentCache: cache,
+		}
+		benchGraphql(params, b)
+	}
+}
@@ -2,10 +2,13 @@ package graphql_test
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
 	"testing"
 
 	"github.com/machship/graphql"
+	"github.com/machship/graphql/language/location"
+	"github.com/machship/graphql/language/parser"
 	"github.com/machship/graphql/testutil"
 	"github.com/machship/graphql/testutil/starwars"
 )
@@ -270,3 +273,86 @@ func TestEmptyStringIsNotNull(t *testing.T) {
 		t.Errorf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, result))
 	}
 }
+
+func TestDoEnforcesParseOptions(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        starwars.Schema,
+		RequestString: `{ hero { friends { friends { name } } } }`,
+		ParseOptions:  parser.ParseOptions{MaxDepth: 3},
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("wrong result, expected one error, got: %v", result.Errors)
+	}
+	expectedLocations := []location.SourceLocation{{Line: 1, Column: 28}}
+	if !reflect.DeepEqual(expectedLocations, result.Errors[0].Locations) {
+		t.Fatalf("Unexpected locations, Diff: %v", testutil.Diff(expectedLocations, result.Errors[0].Locations))
+	}
+}
+
+func TestResultMarshalJSONPreservesSelectionOrder(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: starwars.Schema,
+		RequestString: `{
+			hero {
+				name
+				... on Droid {
+					primaryFunction
+				}
+				id
+				friends { name id }
+			}
+		}`,
+		OrderedResult: true,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"data":{"hero":{"name":"R2-D2","primaryFunction":"Astromech","id":"2001","friends":[` +
+		`{"name":"Luke Skywalker","id":"1000"},` +
+		`{"name":"Han Solo","id":"1002"},` +
+		`{"name":"Leia Organa","id":"1003"}]}}}`
+	if string(b) != expected {
+		t.Fatalf("Unexpected JSON\n got: %s\nwant: %s", b, expected)
+	}
+}
+
+func TestResultMarshalJSONKeepsKeysAddedAfterExecution(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        starwars.Schema,
+		RequestString: `{ hero { name id } }`,
+		OrderedResult: true,
+	})
+	hero := result.Data.(*graphql.OrderedObject).Values["hero"].(*graphql.OrderedObject)
+	if expectedKeys := []string{"name", "id"}; !reflect.DeepEqual(expectedKeys, hero.Keys) {
+		t.Fatalf("Unexpected keys, Diff: %v", testutil.Diff(expectedKeys, hero.Keys))
+	}
+	hero.Values["added"] = true
+	delete(hero.Values, "name")
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"data":{"hero":{"id":"2001","added":true}}}`
+	if string(b) != expected {
+		t.Fatalf("Unexpected JSON\n got: %s\nwant: %s", b, expected)
+	}
+}
+
+func TestResultMarshalJSONSortsFieldsOfUnorderedResults(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        starwars.Schema,
+		RequestString: `{ hero { name id } }`,
+	})
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"data":{"hero":{"id":"2001","name":"R2-D2"}}}`
+	if string(b) != expected {
+		t.Fatalf("Unexpected JSON\n got: %s\nwant: %s", b, expected)
+	}
+}
@@ -0,0 +1,121 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/machship/graphql/language/ast"
+	"github.com/machship/graphql/language/kinds"
+	"github.com/machship/graphql/language/visitor"
+)
+
+// IntrospectionPolicy restricts the introspection a request may use.
+type IntrospectionPolicy struct {
+	// Disabled rejects the __schema and __type fields. __typename is always
+	// allowed.
+	Disabled bool
+	// DisableNonStandard rejects __schema(includeNonStandard: true), which
+	// exposes the applied directives of the schema.
+	DisableNonStandard bool
+}
+
+// IntrospectionPolicyFn returns the IntrospectionPolicy of a request from its
+// context, e.g. to allow introspection only for authenticated staff.
+type IntrospectionPolicyFn func(ctx context.Context) IntrospectionPolicy
+
+// DisableIntrospection is an IntrospectionPolicyFn disabling introspection
+// for every request.
+func DisableIntrospection(ctx context.Context) IntrospectionPolicy {
+	return IntrospectionPolicy{Disabled: true}
+}
+
+const (
+	// IntrospectionDisabledMessage is the message of the errors reported
+	// for introspection fields when introspection is disabled.
+	IntrospectionDisabledMessage = `GraphQL introspection is not allowed, but the query contained __schema or __type.`
+	// NonStandardIntrospectionDisabledMessage is the message of the errors
+	// reported for __schema(includeNonStandard: true) when it is disabled.
+	NonStandardIntrospectionDisabledMessage = `GraphQL introspection of non-standard applied directives is not allowed.`
+)
+
+// restricts reports whether the policy restricts anything.
+func (policy IntrospectionPolicy) restricts() bool {
+	return policy.Disabled || policy.DisableNonStandard
+}
+
+// introspectionPolicy returns the policy of a request from policyFn, or from
+// the policy of the schema if policyFn is nil.
+func introspectionPolicy(schema *Schema, policyFn IntrospectionPolicyFn, ctx context.Context) IntrospectionPolicy {
+	if policyFn == nil {
+		policyFn = schema.introspectionPolicy
+	}
+	if policyFn == nil {
+		return IntrospectionPolicy{}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return policyFn(ctx)
+}
+
+// checkIntrospectionField returns the message of the error to report for a
+// field, if the policy rejects it. includeNonStandard reports whether the
+// field is __schema(includeNonStandard: true).
+func (policy IntrospectionPolicy) checkIntrospectionField(fieldDef *FieldDefinition, includeNonStandard func() bool) string {
+	if fieldDef != SchemaMetaFieldDef && fieldDef != TypeMetaFieldDef {
+		return ""
+	}
+	if policy.Disabled {
+		return IntrospectionDisabledMessage
+	}
+	if policy.DisableNonStandard && fieldDef == SchemaMetaFieldDef && includeNonStandard() {
+		return NonStandardIntrospectionDisabledMessage
+	}
+	return ""
+}
+
+// IntrospectionPolicyRule returns a validation rule rejecting the
+// introspection fields that policy does not allow. Do and Subscribe run it
+// for requests restricted by Params.IntrospectionPolicy or
+// SchemaConfig.IntrospectionPolicy, and the executor rejects those fields as
+// well, for requests executed without validation.
+func IntrospectionPolicyRule(policy IntrospectionPolicy) ValidationRuleFn {
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		visitorOpts := &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.Field: {
+					Kind: func(p visitor.VisitFuncParams) (string, any) {
+						field, ok := p.Node.(*ast.Field)
+						if !ok {
+							return visitor.ActionNoChange, nil
+						}
+						fieldDef := context.FieldDef()
+						message := policy.checkIntrospectionField(fieldDef, func() bool {
+							args := getArgumentValues(fieldDef.Args, field.Arguments, context.VariableValues())
+							includeNonStandard, _ := args[argIncludeNonStandard].(bool)
+							_, isVariable := argumentValueAST(field, argIncludeNonStandard).(*ast.Variable)
+							// the value of a variable is checked when executing if unknown
+							return includeNonStandard || isVariable && context.VariableValues() == nil
+						})
+						if message != "" {
+							return reportError(context, message, []ast.Node{field})
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+			},
+		}
+		return &ValidationRuleInstance{
+			VisitorOpts: visitorOpts,
+		}
+	}
+}
+
+// argumentValueAST returns the value of the named argument of field, if any.
+func argumentValueAST(field *ast.Field, name string) ast.Value {
+	for _, arg := range field.Arguments {
+		if arg.Name != nil && arg.Name.Value == name {
+			return arg.Value
+		}
+	}
+	return nil
+}
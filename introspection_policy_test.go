@@ -0,0 +1,126 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/location"
+	"github.com/machship/graphql/testutil"
+	"github.com/machship/graphql/testutil/starwars"
+)
+
+type staffKey struct{}
+
+// staffOnlyIntrospection allows introspection only for staff, and the
+// applied directives only for admins.
+func staffOnlyIntrospection(ctx context.Context) graphql.IntrospectionPolicy {
+	role, _ := ctx.Value(staffKey{}).(string)
+	return graphql.IntrospectionPolicy{
+		Disabled:           role == "",
+		DisableNonStandard: role != "admin",
+	}
+}
+
+func TestIntrospectionPolicy_DisablesIntrospectionInValidation(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:              starwars.Schema,
+		RequestString:       `{ hero { name } __typename __schema { queryType { name } } }`,
+		IntrospectionPolicy: graphql.DisableIntrospection,
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			testutil.RuleError(graphql.IntrospectionDisabledMessage, 1, 28),
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:              starwars.Schema,
+		RequestString:       `{ hero { name } __typename }`,
+		IntrospectionPolicy: graphql.DisableIntrospection,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestIntrospectionPolicy_PerRequestPolicy(t *testing.T) {
+	query := `{ __type(name: "Query") { name } }`
+	tests := []struct {
+		role    string
+		allowed bool
+	}{
+		{role: "", allowed: false},
+		{role: "staff", allowed: true},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:              starwars.Schema,
+			RequestString:       query,
+			Context:             context.WithValue(context.Background(), staffKey{}, test.role),
+			IntrospectionPolicy: staffOnlyIntrospection,
+		})
+		if allowed := len(result.Errors) == 0; allowed != test.allowed {
+			t.Fatalf("role %q: unexpected result: %v", test.role, result)
+		}
+	}
+}
+
+func TestIntrospectionPolicy_RestrictsNonStandardIntrospection(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:               starwars.Schema.QueryType(),
+		IntrospectionPolicy: staffOnlyIntrospection,
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	query := `query ($all: Boolean) { __schema(includeNonStandard: $all) { queryType { name } } }`
+	tests := []struct {
+		role      string
+		variables map[string]any
+		allowed   bool
+	}{
+		{role: "staff", variables: map[string]any{"all": false}, allowed: true},
+		{role: "staff", variables: map[string]any{"all": true}, allowed: false},
+		{role: "admin", variables: map[string]any{"all": true}, allowed: true},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: test.variables,
+			Context:        context.WithValue(context.Background(), staffKey{}, test.role),
+		})
+		if allowed := len(result.Errors) == 0; allowed != test.allowed {
+			t.Fatalf("role %q, variables %v: unexpected result: %v", test.role, test.variables, result)
+		}
+	}
+}
+
+func TestIntrospectionPolicy_GuardsExecution(t *testing.T) {
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:              starwars.Schema,
+		AST:                 testutil.TestParse(t, `{ hero { name } __type(name: "Query") { name } }`),
+		IntrospectionPolicy: graphql.DisableIntrospection,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"hero":   map[string]any{"name": "R2-D2"},
+			"__type": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   graphql.IntrospectionDisabledMessage,
+				Locations: []location.SourceLocation{{Line: 1, Column: 17}},
+				Path:      []any{"__type"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
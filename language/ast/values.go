@@ -17,6 +17,7 @@ var _ Value = (*FloatValue)(nil)
 var _ Value = (*StringValue)(nil)
 var _ Value = (*BooleanValue)(nil)
 var _ Value = (*EnumValue)(nil)
+var _ Value = (*NullValue)(nil)
 var _ Value = (*ListValue)(nil)
 var _ Value = (*ObjectValue)(nil)
 
@@ -172,6 +173,34 @@ func (v *BooleanValue) GetValue() any {
 	return v.Value
 }
 
+// NullValue implements Node, Value
+type NullValue struct {
+	Kind string
+	Loc  *Location
+}
+
+func NewNullValue(v *NullValue) *NullValue {
+	if v == nil {
+		v = &NullValue{}
+	}
+	return &NullValue{
+		Kind: kinds.NullValue,
+		Loc:  v.Loc,
+	}
+}
+
+func (v *NullValue) GetKind() string {
+	return v.Kind
+}
+
+func (v *NullValue) GetLoc() *Location {
+	return v.Loc
+}
+
+func (v *NullValue) GetValue() any {
+	return nil
+}
+
 // EnumValue implements Node, Value
 type EnumValue struct {
 	Kind  string
@@ -38,6 +38,16 @@ func init() {
 type ParseOptions struct {
 	NoLocation bool
 	NoSource   bool
+
+	// MaxTokens is the maximum number of tokens of the source, or 0 for no
+	// maximum.
+	MaxTokens int
+	// MaxDepth is the maximum nesting depth of selection sets, list and
+	// object values and list types, or 0 for no maximum.
+	MaxDepth int
+	// MaxLength is the maximum length of the source in bytes, or 0 for no
+	// maximum.
+	MaxLength int
 }
 
 type ParseParams struct {
@@ -51,6 +61,11 @@ type Parser struct {
 	Options  ParseOptions
 	PrevEnd  int
 	Token    lexer.Token
+
+	// number of tokens lexed and current nesting depth, for the limits of
+	// Options
+	tokens int
+	depth  int
 }
 
 func Parse(p ParseParams) (*ast.Document, error) {
@@ -108,6 +123,10 @@ func parseName(parser *Parser) (*ast.Name, error) {
 }
 
 func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
+	if opts.MaxLength > 0 && len(s.Body) > opts.MaxLength {
+		description := fmt.Sprintf("Document exceeds the maximum length of %d bytes, parsing aborted.", opts.MaxLength)
+		return &Parser{}, gqlerrors.NewSyntaxError(s, opts.MaxLength, description)
+	}
 	lexToken := lexer.Lex(s)
 	token, err := lexToken(0)
 	if err != nil {
@@ -119,6 +138,7 @@ func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
 		Options:  opts,
 		PrevEnd:  0,
 		Token:    token,
+		tokens:   1,
 	}, nil
 }
 
@@ -315,6 +335,10 @@ func parseVariable(parser *Parser) (*ast.Variable, error) {
  * SelectionSet : { Selection+ }
  */
 func parseSelectionSet(parser *Parser) (*ast.SelectionSet, error) {
+	if err := enter(parser); err != nil {
+		return nil, err
+	}
+	defer leave(parser)
 	start := parser.Token.Start
 	selections := []ast.Selection{}
 	if iSelections, err := reverse(parser,
@@ -560,12 +584,15 @@ func parseFragmentName(parser *Parser) (*ast.Name, error) {
  *   - FloatValue
  *   - StringValue
  *   - BooleanValue
+ *   - NullValue
  *   - EnumValue
  *   - ListValue[?Const]
  *   - ObjectValue[?Const]
  *
  * BooleanValue : one of `true` `false`
  *
+ * NullValue : `null`
+ *
  * EnumValue : Name but not `true`, `false` or `null`
  */
 func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
@@ -606,7 +633,14 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 				Value: value,
 				Loc:   loc(parser, token.Start),
 			}), nil
-		} else if token.Value != "null" {
+		} else if token.Value == "null" {
+			if err := advance(parser); err != nil {
+				return nil, err
+			}
+			return ast.NewNullValue(&ast.NullValue{
+				Loc: loc(parser, token.Start),
+			}), nil
+		} else {
 			if err := advance(parser); err != nil {
 				return nil, err
 			}
@@ -642,6 +676,10 @@ func parseValueValue(parser *Parser) (any, error) {
  *   - [ Value[?Const]+ ]
  */
 func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
+	if err := enter(parser); err != nil {
+		return nil, err
+	}
+	defer leave(parser)
 	start := parser.Token.Start
 	var item parseFn = parseValueValue
 	if isConst {
@@ -670,6 +708,10 @@ func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
  *   - { ObjectField[?Const]+ }
  */
 func parseObject(parser *Parser, isConst bool) (*ast.ObjectValue, error) {
+	if err := enter(parser); err != nil {
+		return nil, err
+	}
+	defer leave(parser)
 	start := parser.Token.Start
 	if _, err := expect(parser, lexer.BRACE_L); err != nil {
 		return nil, err
@@ -775,10 +817,14 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 	// [ String! ]!
 	switch token.Kind {
 	case lexer.BRACKET_L:
-		if err = advance(parser); err != nil {
+		if err = enter(parser); err != nil {
 			return nil, err
 		}
-		if ttype, err = parseType(parser); err != nil {
+		if err = advance(parser); err == nil {
+			ttype, err = parseType(parser)
+		}
+		leave(parser)
+		if err != nil {
 			return nil, err
 		}
 		fallthrough
@@ -1497,9 +1543,33 @@ func advance(parser *Parser) error {
 		return err
 	}
 	parser.Token = token
+	if token.Kind != lexer.EOF {
+		parser.tokens++
+		if max := parser.Options.MaxTokens; max > 0 && parser.tokens > max {
+			description := fmt.Sprintf("Document contains more than %d tokens, parsing aborted.", max)
+			return gqlerrors.NewSyntaxError(parser.Source, token.Start, description)
+		}
+	}
 	return nil
 }
 
+// enter increases the nesting depth of the parser before parsing a nested
+// selection set, value or type, failing if it exceeds Options.MaxDepth.
+// Every successful enter must be followed by a leave.
+func enter(parser *Parser) error {
+	parser.depth++
+	if max := parser.Options.MaxDepth; max > 0 && parser.depth > max {
+		parser.depth--
+		description := fmt.Sprintf("Document exceeds the maximum nesting depth of %d, parsing aborted.", max)
+		return gqlerrors.NewSyntaxError(parser.Source, parser.Token.Start, description)
+	}
+	return nil
+}
+
+func leave(parser *Parser) {
+	parser.depth--
+}
+
 // lookahead retrieves the next token
 func lookahead(parser *Parser) (lexer.Token, error) {
 	return parser.LexToken(parser.Token.End)
@@ -183,13 +183,21 @@ func TestDoesNotAcceptFragmentsSpreadOfOn(t *testing.T) {
 	testErrorMessage(t, test)
 }
 
-func TestDoesNotAllowNullAsValue(t *testing.T) {
-	test := errorMessageTest{
-		`{ fieldWithNullableStringInput(input: null) }'`,
-		`Syntax Error GraphQL (1:39) Unexpected Name "null"`,
-		false,
+func TestParsesNullAsValue(t *testing.T) {
+	astDoc := parse(t, `{ fieldWithNullableStringInput(input: null, list: [null], obj: { a: null }) }`)
+	op := astDoc.Definitions[0].(*ast.OperationDefinition)
+	field := op.SelectionSet.Selections[0].(*ast.Field)
+	if _, ok := field.Arguments[0].Value.(*ast.NullValue); !ok {
+		t.Fatalf("expected NullValue, got %T", field.Arguments[0].Value)
+	}
+	list := field.Arguments[1].Value.(*ast.ListValue)
+	if _, ok := list.Values[0].(*ast.NullValue); !ok {
+		t.Fatalf("expected NullValue in list, got %T", list.Values[0])
+	}
+	obj := field.Arguments[2].Value.(*ast.ObjectValue)
+	if _, ok := obj.Fields[0].Value.(*ast.NullValue); !ok {
+		t.Fatalf("expected NullValue in object, got %T", obj.Fields[0].Value)
 	}
-	testErrorMessage(t, test)
 }
 
 func TestParsesMultiByteCharacters_Unicode(t *testing.T) {
@@ -809,3 +817,62 @@ func toError(err error) *gqlerrors.Error {
 		return nil
 	}
 }
+
+func TestParseEnforcesResourceLimits(t *testing.T) {
+	tests := []struct {
+		source          string
+		options         ParseOptions
+		expectedMessage string
+	}{
+		{
+			`{ a b c }`,
+			ParseOptions{MaxTokens: 4},
+			`Syntax Error GraphQL (1:9) Document contains more than 4 tokens, parsing aborted.`,
+		},
+		{
+			`{ a { b { c } } }`,
+			ParseOptions{MaxDepth: 2},
+			`Syntax Error GraphQL (1:9) Document exceeds the maximum nesting depth of 2, parsing aborted.`,
+		},
+		{
+			`{ a(x: [[[1]]]) }`,
+			ParseOptions{MaxDepth: 3},
+			`Syntax Error GraphQL (1:10) Document exceeds the maximum nesting depth of 3, parsing aborted.`,
+		},
+		{
+			`{ a(x: {y: {z: 1}}) }`,
+			ParseOptions{MaxDepth: 2},
+			`Syntax Error GraphQL (1:12) Document exceeds the maximum nesting depth of 2, parsing aborted.`,
+		},
+		{
+			`query ($x: [[Int]]) { a }`,
+			ParseOptions{MaxDepth: 1},
+			`Syntax Error GraphQL (1:13) Document exceeds the maximum nesting depth of 1, parsing aborted.`,
+		},
+		{
+			`{ field }`,
+			ParseOptions{MaxLength: 5},
+			`Syntax Error GraphQL (1:6) Document exceeds the maximum length of 5 bytes, parsing aborted.`,
+		},
+	}
+	for _, test := range tests {
+		_, err := Parse(ParseParams{Source: test.source, Options: test.options})
+		checkErrorMessage(t, err, test.expectedMessage)
+	}
+}
+
+func TestParseAcceptsDocumentsWithinResourceLimits(t *testing.T) {
+	_, err := Parse(ParseParams{
+		Source:  `{ a { b(x: [1]) } }`,
+		Options: ParseOptions{MaxTokens: 13, MaxDepth: 3, MaxLength: 19},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseAbortsDeeplyNestedDocumentsEarly(t *testing.T) {
+	body := strings.Repeat("{ a ", 100000) + strings.Repeat("}", 100000)
+	_, err := Parse(ParseParams{Source: body, Options: ParseOptions{MaxDepth: 64}})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:257) Document exceeds the maximum nesting depth of 64, parsing aborted.`)
+}
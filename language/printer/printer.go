@@ -388,6 +388,9 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		}
 		return visitor.ActionNoChange, nil
 	},
+	"NullValue": func(p visitor.VisitFuncParams) (string, any) {
+		return visitor.ActionUpdate, "null"
+	},
 	"EnumValue": func(p visitor.VisitFuncParams) (string, any) {
 		switch node := p.Node.(type) {
 		case *ast.EnumValue:
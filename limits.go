@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/machship/graphql/language/ast"
+	"github.com/machship/graphql/language/kinds"
+	"github.com/machship/graphql/language/visitor"
+)
+
+// LimitOption configures the limit rules MaxDepthRule, MaxAliasesRule,
+// MaxRootFieldsRule and MaxDirectivesPerFieldRule.
+type LimitOption func(*limitOptions)
+
+type limitOptions struct {
+	exemptIntrospection bool
+}
+
+// ExemptIntrospection exempts the introspection fields __schema, __type and
+// __typename, and the selections of __schema and __type, from a limit rule.
+func ExemptIntrospection() LimitOption {
+	return func(o *limitOptions) {
+		o.exemptIntrospection = true
+	}
+}
+
+func newLimitOptions(options []LimitOption) limitOptions {
+	o := limitOptions{}
+	for _, option := range options {
+		option(&o)
+	}
+	return o
+}
+
+func (o limitOptions) exempts(field *ast.Field) bool {
+	return o.exemptIntrospection && field.Name != nil && strings.HasPrefix(field.Name.Value, "__")
+}
+
+// operationSubject names an operation in error messages.
+func operationSubject(operation *ast.OperationDefinition) string {
+	if operation.Name != nil {
+		return fmt.Sprintf(`Operation "%v"`, operation.Name.Value)
+	}
+	return "Operation"
+}
+
+// operationLimitRule returns a rule reporting operations for which measure
+// returns more than n.
+func operationLimitRule(measure func(*ValidationContext, *ast.OperationDefinition) int, message func(*ast.OperationDefinition, int) string) ValidationRuleFn {
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		visitorOpts := &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.OperationDefinition: {
+					Kind: func(p visitor.VisitFuncParams) (string, any) {
+						if operation, ok := p.Node.(*ast.OperationDefinition); ok {
+							if count := measure(context, operation); count >= 0 {
+								reportError(context, message(operation, count), []ast.Node{operation})
+							}
+						}
+						return visitor.ActionSkip, nil
+					},
+				},
+			},
+		}
+		return &ValidationRuleInstance{
+			VisitorOpts: visitorOpts,
+		}
+	}
+}
+
+// MaxDepthRule returns a validation rule rejecting operations whose fields
+// are nested more than n levels deep, root fields being at depth 1.
+// Fragment spreads are followed, and cycles between fragments, which
+// NoFragmentCyclesRule reports, are not.
+func MaxDepthRule(n int, options ...LimitOption) ValidationRuleFn {
+	o := newLimitOptions(options)
+	return operationLimitRule(func(context *ValidationContext, operation *ast.OperationDefinition) int {
+		var depths *selectionFold[int]
+		depths = newSelectionFold(context, func(_ Type, field *ast.Field) int {
+			if o.exempts(field) {
+				return 0
+			}
+			return 1 + depths.selectionSet(nil, field.SelectionSet)
+		}, func(a, b int) int { return max(a, b) })
+		if depth := depths.selectionSet(nil, operation.SelectionSet); depth > n {
+			return depth
+		}
+		return -1
+	}, func(operation *ast.OperationDefinition, depth int) string {
+		return fmt.Sprintf(`%v has a depth of %v, which exceeds the maximum depth of %v.`, operationSubject(operation), depth, n)
+	})
+}
+
+// MaxAliasesRule returns a validation rule rejecting operations with more
+// than n aliased fields. The aliases of a fragment count once per spread.
+func MaxAliasesRule(n int, options ...LimitOption) ValidationRuleFn {
+	o := newLimitOptions(options)
+	return operationLimitRule(func(context *ValidationContext, operation *ast.OperationDefinition) int {
+		var aliases *selectionFold[int]
+		aliases = newSelectionFold(context, func(_ Type, field *ast.Field) int {
+			if o.exempts(field) {
+				return 0
+			}
+			count := aliases.selectionSet(nil, field.SelectionSet)
+			if field.Alias != nil {
+				count = addCost(count, 1)
+			}
+			return count
+		}, addCost)
+		if count := aliases.selectionSet(nil, operation.SelectionSet); count > n {
+			return count
+		}
+		return -1
+	}, func(operation *ast.OperationDefinition, count int) string {
+		return fmt.Sprintf(`%v has %v aliases, which exceeds the maximum of %v.`, operationSubject(operation), count, n)
+	})
+}
+
+// MaxRootFieldsRule returns a validation rule rejecting operations selecting
+// more than n root fields, including those selected by fragments. The root
+// fields of a fragment count once per spread.
+func MaxRootFieldsRule(n int, options ...LimitOption) ValidationRuleFn {
+	o := newLimitOptions(options)
+	return operationLimitRule(func(context *ValidationContext, operation *ast.OperationDefinition) int {
+		rootFields := newSelectionFold(context, func(_ Type, field *ast.Field) int {
+			if o.exempts(field) {
+				return 0
+			}
+			return 1
+		}, addCost)
+		if count := rootFields.selectionSet(nil, operation.SelectionSet); count > n {
+			return count
+		}
+		return -1
+	}, func(operation *ast.OperationDefinition, count int) string {
+		return fmt.Sprintf(`%v has %v root fields, which exceeds the maximum of %v.`, operationSubject(operation), count, n)
+	})
+}
+
+// MaxDirectivesPerFieldRule returns a validation rule rejecting fields with
+// more than n directives. With ExemptIntrospection, fragments on the
+// introspection types are exempt as well, as they only apply within the
+// selections of __schema and __type.
+func MaxDirectivesPerFieldRule(n int, options ...LimitOption) ValidationRuleFn {
+	o := newLimitOptions(options)
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		visitorOpts := &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.FragmentDefinition: {
+					Kind: func(p visitor.VisitFuncParams) (string, any) {
+						fragment, ok := p.Node.(*ast.FragmentDefinition)
+						if ok && o.exemptIntrospection && fragment.TypeCondition != nil &&
+							fragment.TypeCondition.Name != nil && strings.HasPrefix(fragment.TypeCondition.Name.Value, "__") {
+							return visitor.ActionSkip, nil
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+				kinds.Field: {
+					Kind: func(p visitor.VisitFuncParams) (string, any) {
+						field, ok := p.Node.(*ast.Field)
+						if !ok {
+							return visitor.ActionNoChange, nil
+						}
+						if o.exempts(field) {
+							return visitor.ActionSkip, nil
+						}
+						if len(field.Directives) > n {
+							reportError(
+								context,
+								fmt.Sprintf(`Field "%v" has %v directives, which exceeds the maximum of %v.`, field.Name.Value, len(field.Directives), n),
+								[]ast.Node{field},
+							)
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+			},
+		}
+		return &ValidationRuleInstance{
+			VisitorOpts: visitorOpts,
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package graphql_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/testutil"
+)
+
+func expectLimitErrors(t *testing.T, rule graphql.ValidationRuleFn, query string, expectedErrors []gqlerrors.FormattedError) {
+	t.Helper()
+	result := graphql.ValidateDocument(&costTestSchema, testutil.TestParse(t, query), []graphql.ValidationRuleFn{rule})
+	if len(expectedErrors) == 0 {
+		if !result.IsValid {
+			t.Fatalf("%v: unexpected errors: %v", query, result.Errors)
+		}
+		return
+	}
+	if !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
+		t.Fatalf("%v: Unexpected errors, Diff: %v", query, testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
+func limitError(message string, line, column int) []gqlerrors.FormattedError {
+	return []gqlerrors.FormattedError{
+		testutil.RuleError(message, line, column),
+	}
+}
+
+func TestMaxDepthRule(t *testing.T) {
+	rule := graphql.MaxDepthRule(3)
+	expectLimitErrors(t, rule, `{ users { friends { name } } }`, nil)
+	expectLimitErrors(t, rule, `query Deep { users { friends { friends { name } } } }`,
+		limitError(`Operation "Deep" has a depth of 4, which exceeds the maximum depth of 3.`, 1, 1))
+	expectLimitErrors(t, rule, `{ users { ...F } } fragment F on User { friends { friends { name } } }`,
+		limitError(`Operation has a depth of 4, which exceeds the maximum depth of 3.`, 1, 1))
+	expectLimitErrors(t, rule, `{ users { ... on User { friends { name } } } }`, nil)
+}
+
+func TestMaxDepthRule_SurvivesFragmentCycles(t *testing.T) {
+	rule := graphql.MaxDepthRule(10)
+	expectLimitErrors(t, rule, `{ users { ...A } } fragment A on User { friends { ...B } } fragment B on User { friends { ...A } }`, nil)
+}
+
+func TestMaxDepthRule_ExemptsIntrospection(t *testing.T) {
+	query := `{ __schema { types { fields { type { name } } } } }`
+	expectLimitErrors(t, graphql.MaxDepthRule(2, graphql.ExemptIntrospection()), query, nil)
+	expectLimitErrors(t, graphql.MaxDepthRule(2), query,
+		limitError(`Operation has a depth of 5, which exceeds the maximum depth of 2.`, 1, 1))
+}
+
+func TestMaxAliasesRule(t *testing.T) {
+	rule := graphql.MaxAliasesRule(2)
+	expectLimitErrors(t, rule, `{ a: users { b: name } }`, nil)
+	expectLimitErrors(t, rule, `{ a: users { b: name c: name } }`,
+		limitError(`Operation has 3 aliases, which exceeds the maximum of 2.`, 1, 1))
+	// the aliases of a fragment count once per spread
+	expectLimitErrors(t, rule, `{ users { ...F ...F } } fragment F on User { a: name b: name }`,
+		limitError(`Operation has 4 aliases, which exceeds the maximum of 2.`, 1, 1))
+	expectLimitErrors(t, graphql.MaxAliasesRule(2, graphql.ExemptIntrospection()), `{ a: __typename b: __typename c: __typename }`, nil)
+}
+
+func TestMaxAliasesRule_RejectsThousandsOfAliases(t *testing.T) {
+	var query strings.Builder
+	query.WriteString("{ ")
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&query, "a%d: search ", i)
+	}
+	query.WriteString("}")
+	expectLimitErrors(t, graphql.MaxAliasesRule(100), query.String(),
+		limitError(`Operation has 5000 aliases, which exceeds the maximum of 100.`, 1, 1))
+}
+
+func TestMaxRootFieldsRule(t *testing.T) {
+	rule := graphql.MaxRootFieldsRule(2)
+	expectLimitErrors(t, rule, `{ users { name } search }`, nil)
+	expectLimitErrors(t, rule, `{ users { name } search ...F } fragment F on Query { other: search }`,
+		limitError(`Operation has 3 root fields, which exceeds the maximum of 2.`, 1, 1))
+	expectLimitErrors(t, rule, `{ search ...F ...F } fragment F on Query { other: search }`,
+		limitError(`Operation has 3 root fields, which exceeds the maximum of 2.`, 1, 1))
+	expectLimitErrors(t, graphql.MaxRootFieldsRule(2, graphql.ExemptIntrospection()), `{ users { name } search __typename }`, nil)
+}
+
+func TestMaxDirectivesPerFieldRule(t *testing.T) {
+	rule := graphql.MaxDirectivesPerFieldRule(2)
+	expectLimitErrors(t, rule, `{ search @include(if: true) @skip(if: false) }`, nil)
+	expectLimitErrors(t, rule, `{ users { name @include(if: true) @skip(if: false) @include(if: true) } }`,
+		limitError(`Field "name" has 3 directives, which exceeds the maximum of 2.`, 1, 11))
+}
+
+func TestMaxDirectivesPerFieldRule_ExemptsIntrospection(t *testing.T) {
+	query := `{
+		__schema { types { name @include(if: true) @skip(if: false) ...T } }
+		__type(name: "User") @include(if: true) @skip(if: false) { name }
+	}
+	fragment T on __Type { kind @include(if: true) @skip(if: false) }`
+	expectLimitErrors(t, graphql.MaxDirectivesPerFieldRule(1, graphql.ExemptIntrospection()), query, nil)
+	expectLimitErrors(t, graphql.MaxDirectivesPerFieldRule(1), query, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "name" has 2 directives, which exceeds the maximum of 1.`, 2, 22),
+		testutil.RuleError(`Field "__type" has 2 directives, which exceeds the maximum of 1.`, 3, 3),
+		testutil.RuleError(`Field "kind" has 2 directives, which exceeds the maximum of 1.`, 5, 25),
+	})
+}
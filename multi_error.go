@@ -0,0 +1,146 @@
+package graphql
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/machship/graphql/gqlerrors"
+)
+
+// MultiError holds several errors. When a resolve function returns a
+// MultiError, or an error created with errors.Join, each of the errors is
+// reported separately with the path of the field.
+type MultiError []error
+
+func (errs MultiError) Error() string {
+	return joinErrorMessages(errs)
+}
+
+func (errs MultiError) Unwrap() []error {
+	return errs
+}
+
+// PathError is an error about a value nested in the value of a field, e.g. an
+// item of a list. It is reported with Path appended to the path of the field.
+type PathError struct {
+	// Path is relative to the field, e.g. []any{3} for the fourth item of a
+	// list or []any{3, "weight"} for a field of that item.
+	Path []any
+	Err  error
+}
+
+func (e *PathError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// NonFatalError holds errors which a resolve function returns along with a
+// value that should still be completed, e.g. a list of which some items
+// could not be fetched. The errors are reported as with MultiError.
+type NonFatalError struct {
+	Errors []error
+}
+
+// NonFatal returns a NonFatalError holding errs, or nil if errs is empty.
+// Returning it from a resolve function, or from a thunk returned by one,
+// reports the errors without discarding the value returned with them:
+//
+//	items, itemErrs := fetchItems(ids)
+//	return items, graphql.NonFatal(itemErrs...)
+func NonFatal(errs ...error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &NonFatalError{Errors: errs}
+}
+
+func (e *NonFatalError) Error() string {
+	return joinErrorMessages(e.Errors)
+}
+
+func (e *NonFatalError) Unwrap() []error {
+	return e.Errors
+}
+
+func joinErrorMessages(errs []error) string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	return strings.Join(messages, "\n")
+}
+
+// expandError splits a located error whose original error holds several
+// errors, e.g. a MultiError, into one located error per held error, and
+// appends the path of PathErrors to the path of the located error.
+func expandError(err *gqlerrors.Error) []*gqlerrors.Error {
+	original := originalError(err)
+	if _, ok := original.(interface{ Unwrap() []error }); !ok {
+		var pathErr *PathError
+		if original == nil || !errors.As(original, &pathErr) {
+			return []*gqlerrors.Error{err}
+		}
+	}
+	expanded := []*gqlerrors.Error{}
+	for _, leaf := range flattenErrors(original, nil) {
+		path := append(append([]any{}, err.Path...), leaf.path...)
+		expanded = append(expanded, gqlerrors.NewErrorWithPath(
+			leaf.err.Error(),
+			err.Nodes,
+			"",
+			nil,
+			[]int{},
+			path,
+			leaf.err,
+		))
+	}
+	return expanded
+}
+
+type pathedError struct {
+	err  error
+	path []any
+}
+
+// flattenErrors returns the errors held by err, recursively, together with
+// the paths of the PathErrors wrapping them.
+func flattenErrors(err error, path []any) []pathedError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *PathError:
+		return flattenErrors(e.Err, append(append([]any{}, path...), e.Path...))
+	case interface{ Unwrap() []error }:
+		leaves := []pathedError{}
+		for _, err := range e.Unwrap() {
+			leaves = append(leaves, flattenErrors(err, path)...)
+		}
+		return leaves
+	}
+	var pathErr *PathError
+	if errors.As(err, &pathErr) {
+		// keep err, which wraps the PathError, but report it at its path
+		path = append(append([]any{}, path...), pathErr.Path...)
+	}
+	return []pathedError{{err: err, path: path}}
+}
+
+// formatMultiError formats an error which is not about a field, e.g. about
+// the variables of a request, with each of the errors of a MultiError
+// formatted separately.
+func formatMultiError(err error) []gqlerrors.FormattedError {
+	errs, ok := err.(MultiError)
+	if !ok {
+		return gqlerrors.FormatErrors(err)
+	}
+	formatted := []gqlerrors.FormattedError{}
+	for _, err := range errs {
+		formatted = append(formatted, formatMultiError(err)...)
+	}
+	return formatted
+}
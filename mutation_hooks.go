@@ -0,0 +1,194 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/ast"
+)
+
+// ErrAbortMutation may be returned by MutationHooks.BeforeField and
+// MutationHooks.AfterField to abort the remaining root fields of a mutation
+// without reporting an error of its own, e.g. after a field failed.
+var ErrAbortMutation = errors.New("mutation aborted")
+
+// MutationHooks are called around the root fields of mutation operations,
+// which are executed serially, e.g. to run a mutation operation in a
+// database transaction and roll it back when any of its fields fails.
+type MutationHooks struct {
+	// BeforeOperation is called before the first root field. The context it
+	// returns, if not nil, is passed to the resolvers and the other hooks,
+	// e.g. to carry a transaction. If it returns an error, no field is
+	// executed and AfterOperation is not called.
+	BeforeOperation func(p MutationOperationParams) (context.Context, error)
+
+	// BeforeField is called before each root field. If it returns an error,
+	// the field and the remaining fields are not executed.
+	BeforeField func(p MutationFieldParams) error
+
+	// AfterField is called after each root field with its completed value
+	// and the errors it raised. If it returns an error, the remaining
+	// fields are not executed.
+	AfterField func(p MutationFieldResultParams) error
+
+	// AfterOperation is called with the result of the operation, including
+	// when a non-null root field nulled the whole result. An error it
+	// returns is added to the errors of the result.
+	AfterOperation func(p MutationOperationResultParams) error
+}
+
+// MutationOperationParams are the parameters of MutationHooks.BeforeOperation.
+type MutationOperationParams struct {
+	Context        context.Context
+	Operation      *ast.OperationDefinition
+	VariableValues map[string]any
+}
+
+// MutationOperationResultParams are the parameters of
+// MutationHooks.AfterOperation.
+type MutationOperationResultParams struct {
+	MutationOperationParams
+	Result *Result
+	// Aborted reports whether some root fields were not executed, or a
+	// non-null root field nulled the whole result.
+	Aborted bool
+}
+
+// MutationFieldParams are the parameters of MutationHooks.BeforeField.
+type MutationFieldParams struct {
+	Context   context.Context
+	FieldName string
+	Path      []any
+	Args      map[string]any
+}
+
+// MutationFieldResultParams are the parameters of MutationHooks.AfterField.
+type MutationFieldResultParams struct {
+	MutationFieldParams
+	Result any
+	Errors []gqlerrors.FormattedError
+}
+
+// executeFieldsWithMutationHooks implements executeFieldsSerially for
+// operations with MutationHooks.
+func executeFieldsWithMutationHooks(p executeFieldsParams, plan []*orderedField, hooks *MutationHooks) (result *Result) {
+	eCtx := p.ExecutionContext
+	operation, _ := eCtx.Operation.(*ast.OperationDefinition)
+	if eCtx.Context == nil {
+		eCtx.Context = context.Background()
+	}
+	operationParams := MutationOperationParams{
+		Context:        eCtx.Context,
+		Operation:      operation,
+		VariableValues: eCtx.VariableValues,
+	}
+	if hooks.BeforeOperation != nil {
+		ctx, err := hooks.BeforeOperation(operationParams)
+		if err != nil {
+			return &Result{
+				Errors: presentErrors(eCtx, NewLocatedError(err, []ast.Node{operation})),
+			}
+		}
+		if ctx != nil {
+			eCtx.Context = ctx
+			operationParams.Context = ctx
+		}
+	}
+
+	aborted := false
+	afterOperation := func(result *Result) {
+		if hooks.AfterOperation == nil {
+			return
+		}
+		err := hooks.AfterOperation(MutationOperationResultParams{
+			MutationOperationParams: operationParams,
+			Result:                  result,
+			Aborted:                 aborted,
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, presentErrors(eCtx, NewLocatedError(err, []ast.Node{operation}))...)
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			// a non-null root field nulled the result
+			aborted = true
+			result = &Result{
+				Errors: append(append([]gqlerrors.FormattedError{}, eCtx.Errors...), presentErrors(eCtx, recoveredError(r, nil, nil))...),
+			}
+			afterOperation(result)
+		}
+	}()
+
+	finalResults := make(map[string]any, len(plan))
+	for _, orderedField := range plan {
+		responseName := orderedField.responseName
+		fieldASTs := orderedField.fieldASTs
+		fieldAST := fieldASTs[0]
+		fieldDef := getFieldDef(eCtx.Schema, p.ParentType, fieldAST.Name.Value)
+		if fieldDef == nil {
+			continue
+		}
+		fieldPath := p.Path.WithKey(responseName)
+		finalResults[responseName] = nil
+
+		if aborted {
+			reportAbortedMutationField(eCtx, fieldAST, fieldDef, fieldPath)
+			continue
+		}
+		fieldParams := MutationFieldParams{
+			Context:   eCtx.Context,
+			FieldName: fieldAST.Name.Value,
+			Path:      fieldPath.AsArray(),
+			Args:      getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues),
+		}
+		if hooks.BeforeField != nil {
+			if err := hooks.BeforeField(fieldParams); err != nil {
+				aborted = true
+				if errors.Is(err, ErrAbortMutation) {
+					reportAbortedMutationField(eCtx, fieldAST, fieldDef, fieldPath)
+				} else {
+					nodes := FieldASTsToNodeASTs(fieldASTs)
+					handleFieldError(NewLocatedErrorWithPath(err, nodes, fieldPath.AsArray()), nodes, fieldPath, fieldDef.Type, eCtx)
+				}
+				continue
+			}
+		}
+
+		errorCount := len(eCtx.Errors)
+		resolved, _ := resolveField(eCtx, p.ParentType, p.Source, orderedField, fieldPath)
+		// complete the field before the next one
+		fieldResult := map[string]any{responseName: resolved}
+		dethunkMapDepthFirst(fieldResult)
+		finalResults[responseName] = fieldResult[responseName]
+
+		if hooks.AfterField != nil {
+			err := hooks.AfterField(MutationFieldResultParams{
+				MutationFieldParams: fieldParams,
+				Result:              fieldResult[responseName],
+				Errors:              eCtx.Errors[errorCount:len(eCtx.Errors):len(eCtx.Errors)],
+			})
+			if err != nil {
+				aborted = true
+				if !errors.Is(err, ErrAbortMutation) {
+					eCtx.Errors = append(eCtx.Errors, presentErrors(eCtx, NewLocatedErrorWithPath(err, FieldASTsToNodeASTs(fieldASTs), fieldPath.AsArray()))...)
+				}
+			}
+		}
+	}
+	result = &Result{
+		Data:   newObject(eCtx, finalResults, plan),
+		Errors: eCtx.Errors,
+	}
+	afterOperation(result)
+	return result
+}
+
+// reportAbortedMutationField reports a root field which was not executed
+// because the mutation was aborted.
+func reportAbortedMutationField(eCtx *executionContext, fieldAST *ast.Field, fieldDef *FieldDefinition, path *ResponsePath) {
+	message := fmt.Sprintf(`Mutation aborted, field "%v" was not executed.`, fieldAST.Name.Value)
+	handleFieldError(gqlerrors.NewFormattedError(message), []ast.Node{fieldAST}, path, fieldDef.Type, eCtx)
+}
@@ -0,0 +1,253 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/location"
+	"github.com/machship/graphql/testutil"
+)
+
+type txKey struct{}
+
+// logTx logs the field being resolved in the fake transaction of
+// transactionHooks, if any.
+func logTx(p graphql.ResolveParams) {
+	if log, _ := p.Context.Value(txKey{}).(*[]string); log != nil {
+		*log = append(*log, p.Info.FieldName)
+	}
+}
+
+var mutationHooksTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Query",
+		Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+	}),
+	Mutation: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"create": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{"name": &graphql.ArgumentConfig{Type: graphql.String}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					logTx(p)
+					return p.Args["name"], nil
+				},
+			},
+			"fail": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					logTx(p)
+					return nil, errors.New("failed")
+				},
+			},
+			"failNonNull": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					logTx(p)
+					return nil, errors.New("failed")
+				},
+			},
+		},
+	}),
+})
+
+// transactionHooks runs mutations in a fake transaction logging the fields
+// it executed and whether it was committed or rolled back.
+func transactionHooks(log *[]string) *graphql.MutationHooks {
+	return &graphql.MutationHooks{
+		BeforeOperation: func(p graphql.MutationOperationParams) (context.Context, error) {
+			*log = append(*log, "begin")
+			return context.WithValue(p.Context, txKey{}, log), nil
+		},
+		BeforeField: func(p graphql.MutationFieldParams) error {
+			*log = append(*log, fmt.Sprintf("before %v %v", p.Path, p.Args))
+			return nil
+		},
+		AfterField: func(p graphql.MutationFieldResultParams) error {
+			*log = append(*log, fmt.Sprintf("after %v %v %v", p.Path, p.Result, len(p.Errors)))
+			if len(p.Errors) > 0 {
+				return graphql.ErrAbortMutation
+			}
+			return nil
+		},
+		AfterOperation: func(p graphql.MutationOperationResultParams) error {
+			if p.Aborted || len(p.Result.Errors) > 0 {
+				*log = append(*log, "rollback")
+			} else {
+				*log = append(*log, "commit")
+			}
+			return nil
+		},
+	}
+}
+
+func TestMutationHooks_CommitsSuccessfulMutations(t *testing.T) {
+	log := []string{}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:         mutationHooksTestSchema.QueryType(),
+		Mutation:      mutationHooksTestSchema.MutationType(),
+		MutationHooks: transactionHooks(&log),
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { a: create(name: "a") b: create(name: "b") }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	expectedLog := []string{
+		"begin",
+		"before [a] map[name:a]", "create", "after [a] a 0",
+		"before [b] map[name:b]", "create", "after [b] b 0",
+		"commit",
+	}
+	if !reflect.DeepEqual(expectedLog, log) {
+		t.Fatalf("Unexpected log, Diff: %v", testutil.Diff(expectedLog, log))
+	}
+}
+
+func TestMutationHooks_AbortsRemainingFields(t *testing.T) {
+	log := []string{}
+	result := graphql.Do(graphql.Params{
+		Schema:        mutationHooksTestSchema,
+		RequestString: `mutation { a: create(name: "a") fail b: create(name: "b") }`,
+		MutationHooks: transactionHooks(&log),
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{"a": "a", "fail": nil, "b": nil},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "failed",
+				Locations: []location.SourceLocation{{Line: 1, Column: 33}},
+				Path:      []any{"fail"},
+			},
+			{
+				Message:   `Mutation aborted, field "create" was not executed.`,
+				Locations: []location.SourceLocation{{Line: 1, Column: 38}},
+				Path:      []any{"b"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	expectedLog := []string{
+		"begin",
+		"before [a] map[name:a]", "create", "after [a] a 0",
+		"before [fail] map[]", "fail", "after [fail] <nil> 1",
+		"rollback",
+	}
+	if !reflect.DeepEqual(expectedLog, log) {
+		t.Fatalf("Unexpected log, Diff: %v", testutil.Diff(expectedLog, log))
+	}
+}
+
+func TestMutationHooks_RollsBackWhenNonNullFieldFails(t *testing.T) {
+	log := []string{}
+	result := graphql.Do(graphql.Params{
+		Schema:        mutationHooksTestSchema,
+		RequestString: `mutation { a: create(name: "a") failNonNull }`,
+		MutationHooks: transactionHooks(&log),
+	})
+	if result.Data != nil || len(result.Errors) != 1 {
+		t.Fatalf("Unexpected result: %v", result)
+	}
+	expectedLog := []string{
+		"begin",
+		"before [a] map[name:a]", "create", "after [a] a 0",
+		"before [failNonNull] map[]", "failNonNull",
+		"rollback",
+	}
+	if !reflect.DeepEqual(expectedLog, log) {
+		t.Fatalf("Unexpected log, Diff: %v", testutil.Diff(expectedLog, log))
+	}
+}
+
+func TestMutationHooks_BeforeFieldErrorAbortsMutation(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        mutationHooksTestSchema,
+		RequestString: `mutation { a: create(name: "a") b: create(name: "b") }`,
+		MutationHooks: &graphql.MutationHooks{
+			BeforeField: func(p graphql.MutationFieldParams) error {
+				return errors.New("not allowed")
+			},
+			AfterOperation: func(p graphql.MutationOperationResultParams) error {
+				return errors.New("rolled back")
+			},
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{"a": nil, "b": nil},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "not allowed",
+				Locations: []location.SourceLocation{{Line: 1, Column: 12}},
+				Path:      []any{"a"},
+			},
+			{
+				Message:   `Mutation aborted, field "create" was not executed.`,
+				Locations: []location.SourceLocation{{Line: 1, Column: 33}},
+				Path:      []any{"b"},
+			},
+			{
+				Message:   "rolled back",
+				Locations: []location.SourceLocation{{Line: 1, Column: 1}},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMutationHooks_AreNotCalledForQueries(t *testing.T) {
+	log := []string{}
+	result := graphql.Do(graphql.Params{
+		Schema:        mutationHooksTestSchema,
+		RequestString: `{ a }`,
+		MutationHooks: transactionHooks(&log),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if len(log) != 0 {
+		t.Fatalf("Unexpected log: %v", log)
+	}
+}
+
+func TestMutationHooks_ReportsAfterOperationErrorWhenNonNullFieldFails(t *testing.T) {
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema: mutationHooksTestSchema,
+		AST:    testutil.TestParse(t, `mutation { failNonNull }`),
+		MutationHooks: &graphql.MutationHooks{
+			AfterOperation: func(p graphql.MutationOperationResultParams) error {
+				return errors.New("rollback failed")
+			},
+		},
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "failed",
+				Locations: []location.SourceLocation{{Line: 1, Column: 12}},
+				Path:      []any{"failNonNull"},
+			},
+			{
+				Message:   "rollback failed",
+				Locations: []location.SourceLocation{{Line: 1, Column: 1}},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
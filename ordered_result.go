@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sort"
+)
+
+// OrderedObject is an object of the Data of a result executed with
+// OrderedResult, in place of a map[string]any. It is marshalled to JSON with
+// its fields in the order they were selected by the query.
+type OrderedObject struct {
+	// Keys are the response keys of the fields, in selection order.
+	Keys []string
+	// Values are the values of the fields by response key.
+	Values map[string]any
+}
+
+// newObject returns the completed fields of an object planned by plan, as an
+// OrderedObject if the result is ordered.
+func newObject(eCtx *executionContext, values map[string]any, plan []*orderedField) any {
+	if !eCtx.orderedResult {
+		return values
+	}
+	keys := make([]string, 0, len(values))
+	for _, field := range plan {
+		if _, ok := values[field.responseName]; ok {
+			keys = append(keys, field.responseName)
+		}
+	}
+	return &OrderedObject{Keys: keys, Values: values}
+}
+
+// MarshalJSON writes the Values of the Keys in order, followed by any other
+// Values in sorted order.
+func (o *OrderedObject) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	written := 0
+	writeField := func(key string) error {
+		value, err := json.Marshal(o.Values[key])
+		if err != nil {
+			return err
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		if written > 0 {
+			buf.WriteByte(',')
+		}
+		written++
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+		return nil
+	}
+	for _, key := range o.Keys {
+		if _, ok := o.Values[key]; !ok {
+			continue
+		}
+		if err := writeField(key); err != nil {
+			return nil, err
+		}
+	}
+	if written < len(o.Values) {
+		rest := make([]string, 0, len(o.Values)-written)
+		for key := range o.Values {
+			if !slices.Contains(o.Keys, key) {
+				rest = append(rest, key)
+			}
+		}
+		sort.Strings(rest)
+		for _, key := range rest {
+			if err := writeField(key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
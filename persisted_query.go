@@ -0,0 +1,189 @@
+package graphql
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/machship/graphql/gqlerrors"
+)
+
+// PersistedQueryStore stores the documents of automatic persisted queries by
+// the hex encoded SHA-256 hash of their request string. Implementations must
+// be safe for concurrent use.
+type PersistedQueryStore interface {
+	// Get returns the request string stored for hash, if any.
+	Get(ctx context.Context, hash string) (query string, ok bool, err error)
+	// Set stores the request string of a validated query under its hash.
+	Set(ctx context.Context, hash string, query string)
+}
+
+// RequestError is an error about a request as a whole, reported with a code
+// in the "code" extension.
+type RequestError struct {
+	Message string
+	Code    string
+}
+
+func (e *RequestError) Error() string {
+	return e.Message
+}
+
+func (e *RequestError) Extensions() map[string]any {
+	return map[string]any{"code": e.Code}
+}
+
+var (
+	// ErrPersistedQueryNotFound is reported for requests carrying only the
+	// hash of a query which is not in the PersistedQueryStore. Clients respond
+	// by sending the hash together with the query.
+	ErrPersistedQueryNotFound = &RequestError{Message: "PersistedQueryNotFound", Code: "PERSISTED_QUERY_NOT_FOUND"}
+
+	// ErrPersistedQueryNotSupported is reported for requests carrying only the
+	// hash of a query when no PersistedQueryStore is configured.
+	ErrPersistedQueryNotSupported = &RequestError{Message: "PersistedQueryNotSupported", Code: "PERSISTED_QUERY_NOT_SUPPORTED"}
+)
+
+// persistedQuery is the "persistedQuery" request extension of the automatic
+// persisted queries protocol.
+type persistedQuery struct {
+	version    int
+	sha256Hash string
+}
+
+// getPersistedQuery returns the persisted query extension of a request, if
+// it has one.
+func getPersistedQuery(extensions map[string]any) (*persistedQuery, error) {
+	ext, ok := extensions["persistedQuery"]
+	if !ok || ext == nil {
+		return nil, nil
+	}
+	fields, ok := ext.(map[string]any)
+	if !ok {
+		return nil, &RequestError{Message: "Invalid persistedQuery extension.", Code: "BAD_REQUEST"}
+	}
+	pq := &persistedQuery{}
+	switch version := fields["version"].(type) {
+	case int:
+		pq.version = version
+	case float64:
+		pq.version = int(version)
+	}
+	if pq.version != 1 {
+		return nil, &RequestError{Message: "Unsupported persisted query version.", Code: "BAD_REQUEST"}
+	}
+	pq.sha256Hash, _ = fields["sha256Hash"].(string)
+	if pq.sha256Hash == "" {
+		return nil, &RequestError{Message: "Invalid persistedQuery extension, sha256Hash is required.", Code: "BAD_REQUEST"}
+	}
+	return pq, nil
+}
+
+// resolvePersistedQuery implements automatic persisted queries for Do: it
+// fills in the request string of requests carrying only the hash of a query,
+// and verifies the hash of requests carrying both. If the query is to be
+// stored once validated, register is not nil.
+func resolvePersistedQuery(p *Params) (register func(), err error) {
+	pq, err := getPersistedQuery(p.Extensions)
+	if pq == nil || err != nil {
+		return nil, err
+	}
+	if p.RequestString == "" {
+		if p.PersistedQueries == nil {
+			return nil, ErrPersistedQueryNotSupported
+		}
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		query, ok, err := p.PersistedQueries.Get(ctx, pq.sha256Hash)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrPersistedQueryNotFound
+		}
+		p.RequestString = query
+		return nil, nil
+	}
+	if p.PersistedQueries == nil {
+		return nil, nil
+	}
+	hash := sha256.Sum256([]byte(p.RequestString))
+	if !strings.EqualFold(hex.EncodeToString(hash[:]), pq.sha256Hash) {
+		return nil, &RequestError{Message: "provided sha does not match query", Code: "BAD_REQUEST"}
+	}
+	store, query := p.PersistedQueries, p.RequestString
+	return func() {
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		store.Set(ctx, strings.ToLower(pq.sha256Hash), query)
+	}, nil
+}
+
+// formatRequestError formats an error about a request as a whole, including
+// the extensions of ExtendedErrors.
+func formatRequestError(err error) gqlerrors.FormattedError {
+	return gqlerrors.FormatError(gqlerrors.NewError(err.Error(), nil, "", nil, []int{}, err))
+}
+
+// MemoryPersistedQueryStore is a PersistedQueryStore holding up to a fixed
+// number of queries in memory, evicting the least recently used one when
+// full.
+type MemoryPersistedQueryStore struct {
+	size int
+
+	mu      sync.Mutex
+	queries map[string]*list.Element
+	// most recently used first
+	recency *list.List
+}
+
+type memoryPersistedQuery struct {
+	hash  string
+	query string
+}
+
+// NewMemoryPersistedQueryStore returns a MemoryPersistedQueryStore holding up
+// to size queries.
+func NewMemoryPersistedQueryStore(size int) *MemoryPersistedQueryStore {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryPersistedQueryStore{
+		size:    size,
+		queries: map[string]*list.Element{},
+		recency: list.New(),
+	}
+}
+
+func (s *MemoryPersistedQueryStore) Get(ctx context.Context, hash string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	element, ok := s.queries[strings.ToLower(hash)]
+	if !ok {
+		return "", false, nil
+	}
+	s.recency.MoveToFront(element)
+	return element.Value.(*memoryPersistedQuery).query, true, nil
+}
+
+func (s *MemoryPersistedQueryStore) Set(ctx context.Context, hash string, query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if element, ok := s.queries[hash]; ok {
+		s.recency.MoveToFront(element)
+		return
+	}
+	s.queries[hash] = s.recency.PushFront(&memoryPersistedQuery{hash: hash, query: query})
+	for s.recency.Len() > s.size {
+		oldest := s.recency.Back()
+		s.recency.Remove(oldest)
+		delete(s.queries, oldest.Value.(*memoryPersistedQuery).hash)
+	}
+}
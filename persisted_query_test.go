@@ -0,0 +1,135 @@
+package graphql_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/testutil"
+	"github.com/machship/graphql/testutil/starwars"
+)
+
+func persistedQueryExtensions(query string) map[string]any {
+	hash := sha256.Sum256([]byte(query))
+	return map[string]any{
+		"persistedQuery": map[string]any{
+			"version":    float64(1),
+			"sha256Hash": hex.EncodeToString(hash[:]),
+		},
+	}
+}
+
+func TestDo_PersistedQueryRoundTrip(t *testing.T) {
+	store := graphql.NewMemoryPersistedQueryStore(10)
+	schema := starwars.Schema
+	query := `{ hero { name } }`
+	extensions := persistedQueryExtensions(query)
+
+	// the client first sends only the hash
+	result := graphql.Do(graphql.Params{
+		Schema:           schema,
+		Extensions:       extensions,
+		PersistedQueries: store,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "PersistedQueryNotFound" {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if result.Errors[0].Extensions["code"] != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Fatalf("Unexpected extensions: %v", result.Errors[0].Extensions)
+	}
+
+	// then the hash together with the query, which registers it
+	result = graphql.Do(graphql.Params{
+		Schema:           schema,
+		RequestString:    query,
+		Extensions:       extensions,
+		PersistedQueries: store,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+
+	// and from then on only the hash
+	result = graphql.Do(graphql.Params{
+		Schema:           schema,
+		Extensions:       extensions,
+		PersistedQueries: store,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]any{
+		"hero": map[string]any{"name": "R2-D2"},
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestDo_PersistedQueryHashMismatch(t *testing.T) {
+	store := graphql.NewMemoryPersistedQueryStore(10)
+	result := graphql.Do(graphql.Params{
+		Schema:           starwars.Schema,
+		RequestString:    `{ hero { name } }`,
+		Extensions:       persistedQueryExtensions(`{ hero { __typename } }`),
+		PersistedQueries: store,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != "provided sha does not match query" {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if result.Errors[0].Extensions["code"] != "BAD_REQUEST" {
+		t.Fatalf("Unexpected extensions: %v", result.Errors[0].Extensions)
+	}
+	hash := sha256.Sum256([]byte(`{ hero { __typename } }`))
+	if _, ok, _ := store.Get(context.Background(), hex.EncodeToString(hash[:])); ok {
+		t.Fatal("Expected the mismatched query not to be registered")
+	}
+}
+
+func TestDo_PersistedQueryNotRegisteredWhenInvalid(t *testing.T) {
+	store := graphql.NewMemoryPersistedQueryStore(10)
+	query := `{ unknown }`
+	hash := sha256.Sum256([]byte(query))
+	result := graphql.Do(graphql.Params{
+		Schema:           starwars.Schema,
+		RequestString:    query,
+		Extensions:       persistedQueryExtensions(query),
+		PersistedQueries: store,
+	})
+	if len(result.Errors) == 0 {
+		t.Fatal("Expected validation errors")
+	}
+	if _, ok, _ := store.Get(context.Background(), hex.EncodeToString(hash[:])); ok {
+		t.Fatal("Expected the invalid query not to be registered")
+	}
+}
+
+func TestDo_PersistedQueryNotSupported(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:     starwars.Schema,
+		Extensions: persistedQueryExtensions(`{ hero { name } }`),
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != "PERSISTED_QUERY_NOT_SUPPORTED" {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestMemoryPersistedQueryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	store := graphql.NewMemoryPersistedQueryStore(2)
+	store.Set(ctx, "a", "{ a }")
+	store.Set(ctx, "b", "{ b }")
+	if _, ok, _ := store.Get(ctx, "a"); !ok {
+		t.Fatal("Expected a to be stored")
+	}
+	store.Set(ctx, "c", "{ c }")
+	if _, ok, _ := store.Get(ctx, "b"); ok {
+		t.Fatal("Expected b to be evicted")
+	}
+	if query, ok, _ := store.Get(ctx, "a"); !ok || query != "{ a }" {
+		t.Fatalf("Unexpected query for a: %q", query)
+	}
+}
@@ -0,0 +1,175 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/machship/graphql/language/ast"
+	"github.com/machship/graphql/language/parser"
+	"github.com/machship/graphql/language/source"
+)
+
+// PreparedOperation is an operation which has been parsed and validated once
+// and can be executed many times. The fields to execute for each selection
+// set and runtime type are computed on first use and reused by later
+// executions, unless they depend on variables through @skip or @include.
+//
+// A PreparedOperation is safe for concurrent use.
+type PreparedOperation struct {
+	schema        Schema
+	document      *ast.Document
+	operationName string
+
+	// cached field plans, keyed by planKey
+	plans sync.Map
+	// fields of cached plans, whose selection sets may be planned in turn
+	stableFields sync.Map
+	// number of plans collected by the executions of the operation
+	collected atomic.Int64
+}
+
+// planKey identifies the selection sets of a field of a cached plan, or of
+// the operation if field is nil, completed for a runtime type.
+type planKey struct {
+	runtimeType *Object
+	field       *orderedField
+}
+
+// Prepare parses and validates document against schema and returns the
+// operation named operationName, or its only operation if operationName is
+// empty, prepared for execution. Parse errors are returned as is and
+// validation errors as a MultiError of gqlerrors.FormattedError.
+func Prepare(schema Schema, document string, operationName string) (*PreparedOperation, error) {
+	src := source.NewSource(&source.Source{
+		Body: []byte(document),
+		Name: "GraphQL request",
+	})
+	AST, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		return nil, err
+	}
+
+	validationResult := ValidateDocument(&schema, AST, nil)
+	if !validationResult.IsValid {
+		errs := MultiError{}
+		for _, err := range validationResult.Errors {
+			errs = append(errs, err)
+		}
+		return nil, errs
+	}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range AST.Definitions {
+		definition, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" && operation != nil {
+			return nil, fmt.Errorf("must provide operation name if query contains multiple operations")
+		}
+		if operationName == "" || definition.GetName() != nil && definition.GetName().Value == operationName {
+			operation = definition
+		}
+	}
+	if operation == nil {
+		if operationName != "" {
+			return nil, fmt.Errorf(`unknown operation named "%v"`, operationName)
+		}
+		return nil, fmt.Errorf(`must provide an operation`)
+	}
+
+	return &PreparedOperation{
+		schema:        schema,
+		document:      AST,
+		operationName: operationName,
+	}, nil
+}
+
+// Execute executes the prepared operation with the given root value and
+// variable values.
+func (op *PreparedOperation) Execute(ctx context.Context, root any, variables map[string]any) *Result {
+	return Execute(ExecuteParams{
+		Schema:        op.schema,
+		Root:          root,
+		AST:           op.document,
+		OperationName: op.operationName,
+		Args:          variables,
+		Context:       ctx,
+		prepared:      op,
+	})
+}
+
+// Document returns the parsed document of the prepared operation.
+func (op *PreparedOperation) Document() *ast.Document {
+	return op.document
+}
+
+// fieldPlan returns the ordered fields to execute for the selection sets of
+// field, or of the operation if field is nil, on runtimeType. collect collects
+// the fields, recording whether they depend on variables. The plan is cached
+// by the prepared operation being executed, if any.
+func fieldPlan(eCtx *executionContext, runtimeType *Object, field *orderedField, collect func(usesVariables *bool) map[string][]*ast.Field) []*orderedField {
+	op := eCtx.prepared
+	if op == nil {
+		return orderedFields(collect(nil))
+	}
+	// fields of uncached plans are collected anew by each execution
+	if field != nil {
+		if _, ok := op.stableFields.Load(field); !ok {
+			op.collected.Add(1)
+			return orderedFields(collect(nil))
+		}
+	}
+	key := planKey{runtimeType: runtimeType, field: field}
+	if plan, ok := op.plans.Load(key); ok {
+		return plan.([]*orderedField)
+	}
+
+	op.collected.Add(1)
+	usesVariables := false
+	plan := orderedFields(collect(&usesVariables))
+	if usesVariables {
+		return plan
+	}
+	actual, loaded := op.plans.LoadOrStore(key, plan)
+	if !loaded {
+		for _, field := range plan {
+			op.stableFields.Store(field, true)
+		}
+	}
+	return actual.([]*orderedField)
+}
+
+// selectionDirectives returns the directives of a selection.
+func selectionDirectives(selection ast.Selection) []*ast.Directive {
+	switch selection := selection.(type) {
+	case *ast.Field:
+		return selection.Directives
+	case *ast.InlineFragment:
+		return selection.Directives
+	case *ast.FragmentSpread:
+		return selection.Directives
+	}
+	return nil
+}
+
+// conditionUsesVariables reports whether the @skip or @include directives
+// among directives depend on variables.
+func conditionUsesVariables(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		if directive == nil || directive.Name == nil {
+			continue
+		}
+		if directive.Name.Value != SkipDirective.Name && directive.Name.Value != IncludeDirective.Name {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if _, ok := arg.Value.(*ast.Variable); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPrepare_CollectsStablePlansOnce(t *testing.T) {
+	schema, err := NewSchema(SchemaConfig{
+		Query: NewObject(ObjectConfig{
+			Name: "Query",
+			Fields: Fields{
+				"test": &Field{
+					Type: NewObject(ObjectConfig{
+						Name: "Test",
+						Fields: Fields{
+							"a": &Field{Type: String},
+							"b": &Field{Type: String},
+						},
+					}),
+					Resolve: func(p ResolveParams) (any, error) {
+						return map[string]any{"a": "a", "b": "b"}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	tests := []struct {
+		query string
+		// plans collected by the first and by each later execution
+		first, later int64
+	}{
+		{query: `{ test { a b } }`, first: 2, later: 0},
+		{query: `query ($withB: Boolean!) { test { a b @include(if: $withB) } }`, first: 2, later: 1},
+	}
+	for _, test := range tests {
+		op, err := Prepare(schema, test.query, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i := 0; i < 3; i++ {
+			result := op.Execute(context.Background(), nil, map[string]any{"withB": i%2 == 0})
+			if len(result.Errors) > 0 {
+				t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+			}
+			if expected := test.first + int64(i)*test.later; op.collected.Load() != expected {
+				t.Fatalf("%v: expected %v plans collected after %v executions, got %v", test.query, expected, i+1, op.collected.Load())
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/testutil"
+	"github.com/machship/graphql/testutil/starwars"
+)
+
+func TestPrepare_ExecutesConcurrentlyWithDifferentVariables(t *testing.T) {
+	op, err := graphql.Prepare(starwars.Schema, `
+		query Human($id: String!, $withPlanet: Boolean!) {
+			human(id: $id) {
+				...HumanName
+				homePlanet @include(if: $withPlanet)
+			}
+		}
+		fragment HumanName on Human {
+			name
+		}
+		query Other {
+			hero { id }
+		}
+	`, "Human")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := []string{"1000", "1001"}
+	names := []string{"Luke Skywalker", "Darth Vader"}
+	expectedFor := func(i int) map[string]any {
+		human := map[string]any{"name": names[i%2]}
+		if i%3 == 0 {
+			human["homePlanet"] = "Tatooine"
+		}
+		return map[string]any{"human": human}
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*graphql.Result, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = op.Execute(context.Background(), nil, map[string]any{
+				"id":         ids[i%2],
+				"withPlanet": i%3 == 0,
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		if expected := expectedFor(i); !reflect.DeepEqual(expected, result.Data) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+		}
+	}
+}
+
+func TestPrepare_ReusesPlansAcrossExecutions(t *testing.T) {
+	op, err := graphql.Prepare(starwars.Schema, `{ hero { name ... on Droid { primaryFunction } } }`, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]any{
+		"hero": map[string]any{
+			"name":            "R2-D2",
+			"primaryFunction": "Astromech",
+		},
+	}
+	for i := 0; i < 3; i++ {
+		result := op.Execute(context.Background(), nil, nil)
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		if !reflect.DeepEqual(expected, result.Data) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+		}
+	}
+}
+
+func TestPrepare_ReturnsValidationErrors(t *testing.T) {
+	_, err := graphql.Prepare(starwars.Schema, `{ hero { unknown } }`, "")
+	var errs graphql.MultiError
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+	formatted, ok := errs[0].(gqlerrors.FormattedError)
+	if !ok {
+		t.Fatalf("expected a formatted error, got %T", errs[0])
+	}
+	if expected := `Cannot query field "unknown" on type "Character".`; formatted.Message != expected {
+		t.Fatalf("expected error %q, got %q", expected, formatted.Message)
+	}
+}
+
+func TestPrepare_RequiresKnownOperation(t *testing.T) {
+	_, err := graphql.Prepare(starwars.Schema, `query A { hero { name } }`, "B")
+	if err == nil || err.Error() != `unknown operation named "B"` {
+		t.Fatalf("expected an unknown operation error, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/ast"
+)
+
+// recoveredError converts a value recovered from a panic into a located
+// error. Panics with a *gqlerrors.Error or a gqlerrors.FormattedError are
+// raised by the executor itself to propagate field errors and are located as
+// is. Any other value, e.g. from a resolve function panicking, is converted
+// to an error and the stack of the panicking goroutine is captured in the
+// Stack of the returned error, so recoveredError must be called from the
+// deferred function which recovered r.
+func recoveredError(r any, nodes []ast.Node, path []any) *gqlerrors.Error {
+	switch r.(type) {
+	case *gqlerrors.Error, gqlerrors.FormattedError:
+		return NewLocatedErrorWithPath(r, nodes, path)
+	}
+	err := NewLocatedErrorWithPath(panicValueError(r), nodes, path)
+	err.Stack = string(debug.Stack())
+	return err
+}
+
+// panicValueError returns r as an error, keeping errors as they are.
+func panicValueError(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
+
+// hasStackTrace reports whether err carries the stack of a recovered panic
+// rather than the default stack, i.e. its message.
+func hasStackTrace(err *gqlerrors.Error) bool {
+	return err.Stack != "" && err.Stack != err.Message
+}
+
+// withStackTrace returns formatted with the stack captured in err added as
+// its "stacktrace" extension, one element per line, if there is one.
+func withStackTrace(formatted gqlerrors.FormattedError, err error) gqlerrors.FormattedError {
+	located, ok := err.(*gqlerrors.Error)
+	if !ok || !hasStackTrace(located) {
+		return formatted
+	}
+	extensions := make(map[string]any, len(formatted.Extensions)+1)
+	for key, value := range formatted.Extensions {
+		extensions[key] = value
+	}
+	extensions["stacktrace"] = strings.Split(strings.TrimSpace(located.Stack), "\n")
+	formatted.Extensions = extensions
+	return formatted
+}
+
+// extensionPanicError formats a value recovered from a panic in the named
+// function of an extension, exposing the stack trace if debug is set.
+func extensionPanicError(name string, r any, debug bool) gqlerrors.FormattedError {
+	err := recoveredError(fmt.Errorf("%s: %w", name, panicValueError(r)), nil, nil)
+	formatted := gqlerrors.FormatError(err)
+	if debug {
+		formatted = withStackTrace(formatted, err)
+	}
+	return formatted
+}
@@ -1725,6 +1725,9 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 // Note that this only validates literal values, variables are assumed to
 // provide values of the correct type.
 func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
+	if _, ok := valueAST.(*ast.NullValue); ok {
+		valueAST = nil
+	}
 	if _, ok := ttype.(*NonNull); !ok {
 		if valueAST == nil {
 			return true, nil
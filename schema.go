@@ -1,5 +1,10 @@
 package graphql
 
+import (
+	"fmt"
+	"reflect"
+)
+
 type SchemaConfig struct {
 	Query        *Object
 	Mutation     *Object
@@ -7,6 +12,34 @@ type SchemaConfig struct {
 	Types        []Type
 	Directives   []*Directive
 	Extensions   []Extension
+
+	// ErrorPresenter, if set, turns the errors raised while executing fields
+	// into the errors sent to clients, e.g. MaskingErrorPresenter(nil).
+	// It may be overridden per request with Params.ErrorPresenter.
+	ErrorPresenter ErrorPresenterFn
+
+	// MutationHooks, if set, are called around the root fields of mutation
+	// operations. They may be overridden per request with
+	// Params.MutationHooks.
+	MutationHooks *MutationHooks
+
+	// IntrospectionPolicy, if set, restricts the introspection of each
+	// request, e.g. DisableIntrospection. It may be overridden per request
+	// with Params.IntrospectionPolicy.
+	IntrospectionPolicy IntrospectionPolicyFn
+
+	// Authorizer checks the applied @auth directives of fields and types
+	// without an Authorize policy. If nil, such directives deny access.
+	Authorizer AuthorizeFn
+
+	// AuthorizationFailure is how denied access is reported, by default
+	// AuthorizationFailureNull.
+	AuthorizationFailure AuthorizationFailure
+
+	// ResolveMethods makes fields without a Resolve function call getter
+	// methods of their source with MethodResolveFn, instead of resolving to
+	// null when no struct field matches.
+	ResolveMethods bool
 }
 
 type TypeMap map[string]Type
@@ -34,6 +67,9 @@ type TypeMap map[string]Type
 //	  directives: specifiedDirectives.concat([ myCustomDirective ]),
 //	})
 type Schema struct {
+	// identity of the schema, changed when types are added to it
+	id uint64
+
 	typeMap    TypeMap
 	directives []*Directive
 
@@ -42,7 +78,17 @@ type Schema struct {
 	subscriptionType *Object
 	implementations  map[string][]*Object
 	possibleTypeMap  map[string]map[string]bool
+	goTypeMap        map[reflect.Type][]*Object
 	extensions       []Extension
+	errorPresenter   ErrorPresenterFn
+	mutationHooks    *MutationHooks
+
+	introspectionPolicy IntrospectionPolicyFn
+
+	authorizer           AuthorizeFn
+	authorizationFailure AuthorizationFailure
+
+	resolveMethods bool
 
 	appliedDirectives []*Directive
 }
@@ -54,7 +100,7 @@ func (s *Schema) AppliedDirectives() []*Directive {
 func NewSchema(config SchemaConfig) (Schema, error) {
 	var err error
 
-	schema := Schema{}
+	schema := Schema{id: schemaIDs.Add(1)}
 
 	if err = invariant(config.Query != nil, "Schema query must be Object Type but got: nil."); err != nil {
 		return schema, err
@@ -143,11 +189,23 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		}
 	}
 
+	// Index objects by their declared Go types
+	if err := schema.buildGoTypeMap(); err != nil {
+		return schema, err
+	}
+
 	// Add extensions from config
 	if len(config.Extensions) != 0 {
 		schema.extensions = config.Extensions
 	}
 
+	schema.errorPresenter = config.ErrorPresenter
+	schema.mutationHooks = config.MutationHooks
+	schema.introspectionPolicy = config.IntrospectionPolicy
+	schema.authorizer = config.Authorizer
+	schema.authorizationFailure = config.AuthorizationFailure
+	schema.resolveMethods = config.ResolveMethods
+
 	return schema, nil
 }
 
@@ -184,7 +242,11 @@ func (gq *Schema) AddImplementation() error {
 		}
 	}
 
-	return nil
+	// types may have been added, so documents validated against the schema
+	// before are no longer known to be valid
+	gq.id = schemaIDs.Add(1)
+
+	return gq.buildGoTypeMap()
 }
 
 // Edited. To check add Types at RunTime..
@@ -202,6 +264,57 @@ func (gq *Schema) AppendType(objectType Type) error {
 	return gq.AddImplementation()
 }
 
+// buildGoTypeMap indexes the objects of the schema by the Go types declared
+// in ObjectConfig.GoTypes, and ensures that no Go type maps to two possible
+// types of the same abstract type.
+func (gq *Schema) buildGoTypeMap() error {
+	goTypeMap := map[reflect.Type][]*Object{}
+	for _, ttype := range gq.typeMap {
+		if ttype, ok := ttype.(*Object); ok {
+			for _, goType := range ttype.GoTypes() {
+				goTypeMap[goType] = append(goTypeMap[goType], ttype)
+			}
+		}
+	}
+	gq.goTypeMap = goTypeMap
+
+	for _, ttype := range gq.typeMap {
+		abstractType, ok := ttype.(Abstract)
+		if !ok {
+			continue
+		}
+		declaredBy := map[reflect.Type]*Object{}
+		for _, possibleType := range gq.PossibleTypes(abstractType) {
+			for _, goType := range possibleType.GoTypes() {
+				if other, ok := declaredBy[goType]; ok && other != possibleType {
+					return fmt.Errorf(`Go type %v is declared by both "%v" and "%v", `+
+						`which are possible types of "%v".`, goType, other, possibleType, abstractType)
+				}
+				declaredBy[goType] = possibleType
+			}
+		}
+	}
+	return nil
+}
+
+// objectForGoType returns the possible type of abstractType declaring the Go
+// type goType, or its element type if goType is a pointer, or nil if there is
+// none.
+func (gq *Schema) objectForGoType(abstractType Abstract, goType reflect.Type) *Object {
+	for goType != nil {
+		for _, ttype := range gq.goTypeMap[goType] {
+			if gq.IsPossibleType(abstractType, ttype) {
+				return ttype
+			}
+		}
+		if goType.Kind() != reflect.Ptr {
+			break
+		}
+		goType = goType.Elem()
+	}
+	return nil
+}
+
 func (gq *Schema) QueryType() *Object {
 	return gq.queryType
 }
@@ -0,0 +1,384 @@
+package graphql
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// TagDirectiveName is the name of the applied directive tagging a field,
+// type, enum value or input field for TagVisibility, e.g. @tag(name: "internal").
+const TagDirectiveName = "tag"
+
+// Visibility decides which elements of a schema a view of it exposes, e.g. to
+// show internal and partner clients different slices of the same schema. Nil
+// predicates expose every element.
+//
+// Elements referring to hidden types are hidden as well, e.g. the fields and
+// arguments of a hidden type. The predicates must keep the view valid, e.g.
+// keep some field of every visible object and the fields of the interfaces
+// it implements.
+type Visibility struct {
+	// Type reports whether a named type is visible. The query type and the
+	// introspection types are always visible.
+	Type func(ttype Type) bool
+
+	// Field reports whether a field of an object or interface is visible.
+	Field func(parent Type, field *FieldDefinition) bool
+
+	// Argument reports whether an argument of a field is visible.
+	Argument func(field *FieldDefinition, arg *Argument) bool
+
+	// EnumValue reports whether a value of an enum is visible.
+	EnumValue func(enum *Enum, value *EnumValueDefinition) bool
+
+	// InputField reports whether a field of an input object is visible.
+	InputField func(parent *InputObject, field *InputObjectField) bool
+}
+
+// TagVisibility returns a Visibility exposing the elements tagged with one of
+// tags, with applied @tag(name:) directives, and the untagged elements.
+func TagVisibility(tags ...string) Visibility {
+	visible := func(directives []*AppliedDirective) bool {
+		tagged := false
+		for _, directive := range directives {
+			if directive == nil || directive.Name != TagDirectiveName {
+				continue
+			}
+			tagged = true
+			for _, arg := range directive.Args {
+				if name, ok := arg.Value.(string); ok && arg.Name == "name" && slices.Contains(tags, name) {
+					return true
+				}
+			}
+		}
+		return !tagged
+	}
+	return Visibility{
+		Type: func(ttype Type) bool {
+			return visible(ttype.AppliedDirectives())
+		},
+		Field: func(parent Type, field *FieldDefinition) bool {
+			return visible(field.Directives)
+		},
+		EnumValue: func(enum *Enum, value *EnumValueDefinition) bool {
+			config := enum.enumConfig.Values[value.Name]
+			return config == nil || visible(config.Directives)
+		},
+		InputField: func(parent *InputObject, field *InputObjectField) bool {
+			return visible(field.Directives)
+		},
+	}
+}
+
+// NewSchemaView returns a view of schema exposing only the elements which
+// visibility allows, to validate, introspect and execute requests with. The
+// view shares the resolvers and settings of schema.
+func NewSchemaView(schema Schema, visibility Visibility) (Schema, error) {
+	b := &schemaViewBuilder{
+		schema:     &schema,
+		visibility: visibility,
+		types:      map[string]Type{},
+	}
+	config := SchemaConfig{
+		Query:      b.newObject(schema.QueryType()),
+		Directives: schema.Directives(),
+	}
+	if schema.MutationType() != nil {
+		config.Mutation, _ = b.named(schema.MutationType()).(*Object)
+	}
+	if schema.SubscriptionType() != nil {
+		config.Subscription, _ = b.named(schema.SubscriptionType()).(*Object)
+	}
+	for _, ttype := range schema.TypeMap() {
+		if view := b.named(ttype); view != nil {
+			config.Types = append(config.Types, view)
+		}
+	}
+
+	view, err := NewSchema(config)
+	if err != nil {
+		return view, err
+	}
+	view.extensions = schema.extensions
+	view.errorPresenter = schema.errorPresenter
+	view.mutationHooks = schema.mutationHooks
+	view.introspectionPolicy = schema.introspectionPolicy
+	view.authorizer = schema.authorizer
+	view.authorizationFailure = schema.authorizationFailure
+	view.appliedDirectives = schema.appliedDirectives
+	return view, nil
+}
+
+// schemaViewBuilder builds the types of a view of a schema.
+type schemaViewBuilder struct {
+	schema     *Schema
+	visibility Visibility
+	// types of the view by name, nil if hidden
+	types map[string]Type
+}
+
+// named returns the view of a named type, or nil if it is hidden.
+func (b *schemaViewBuilder) named(ttype Type) Type {
+	name := ttype.Name()
+	if view, ok := b.types[name]; ok {
+		return view
+	}
+	if strings.HasPrefix(name, "__") {
+		b.types[name] = ttype
+		return ttype
+	}
+	if b.visibility.Type != nil && !b.visibility.Type(ttype) {
+		b.types[name] = nil
+		return nil
+	}
+	switch ttype := ttype.(type) {
+	case *Object:
+		return b.newObject(ttype)
+	case *Interface:
+		return b.newInterface(ttype)
+	case *Union:
+		return b.newUnion(ttype)
+	case *Enum:
+		return b.newEnum(ttype)
+	case *InputObject:
+		return b.newInputObject(ttype)
+	}
+	b.types[name] = ttype
+	return ttype
+}
+
+// wrapped returns the view of a possibly wrapped type, or nil if its named
+// type is hidden.
+func (b *schemaViewBuilder) wrapped(ttype Type) Type {
+	switch ttype := ttype.(type) {
+	case *List:
+		if ofType := b.wrapped(ttype.OfType); ofType != nil {
+			return NewList(ofType)
+		}
+		return nil
+	case *NonNull:
+		if ofType := b.wrapped(ttype.OfType); ofType != nil {
+			return NewNonNull(ofType)
+		}
+		return nil
+	case nil:
+		return nil
+	}
+	return b.named(ttype)
+}
+
+func (b *schemaViewBuilder) newObject(object *Object) *Object {
+	goTypes := []any{}
+	for _, goType := range object.goTypes {
+		goTypes = append(goTypes, goType)
+	}
+	view := NewObject(ObjectConfig{
+		Name:        object.Name(),
+		Description: object.Description(),
+		IsTypeOf:    object.IsTypeOf,
+		Authorize:   object.Authorize,
+		Directives:  object.directives,
+		GoTypes:     goTypes,
+		Interfaces: InterfacesThunk(func() []*Interface {
+			interfaces := []*Interface{}
+			for _, iface := range object.Interfaces() {
+				if view, ok := b.named(iface).(*Interface); ok {
+					interfaces = append(interfaces, view)
+				}
+			}
+			return interfaces
+		}),
+		Fields: FieldsThunk(func() Fields {
+			return b.fields(object, object.Fields())
+		}),
+	})
+	b.types[object.Name()] = view
+	return view
+}
+
+func (b *schemaViewBuilder) newInterface(iface *Interface) *Interface {
+	view := NewInterface(InterfaceConfig{
+		Name:        iface.Name(),
+		Description: iface.Description(),
+		ResolveType: b.resolveType(iface.ResolveType),
+		Authorize:   iface.Authorize,
+		Directives:  iface.directives,
+		Fields: FieldsThunk(func() Fields {
+			return b.fields(iface, iface.Fields())
+		}),
+	})
+	b.types[iface.Name()] = view
+	return view
+}
+
+func (b *schemaViewBuilder) newUnion(union *Union) *Union {
+	view := NewUnion(UnionConfig{
+		Name:        union.Name(),
+		Description: union.Description(),
+		ResolveType: b.resolveType(union.ResolveType),
+		Directives:  union.directives,
+		Types: UnionTypesThunk(func() []*Object {
+			types := []*Object{}
+			for _, ttype := range union.Types() {
+				if view, ok := b.named(ttype).(*Object); ok {
+					types = append(types, view)
+				}
+			}
+			return types
+		}),
+	})
+	b.types[union.Name()] = view
+	return view
+}
+
+func (b *schemaViewBuilder) newEnum(enum *Enum) *Enum {
+	values := EnumValueConfigMap{}
+	for _, value := range enum.Values() {
+		if b.visibility.EnumValue != nil && !b.visibility.EnumValue(enum, value) {
+			continue
+		}
+		config := &EnumValueConfig{
+			Value:             value.Value,
+			DeprecationReason: value.DeprecationReason,
+			Description:       value.Description,
+		}
+		if valueConfig := enum.enumConfig.Values[value.Name]; valueConfig != nil {
+			config.Directives = valueConfig.Directives
+		}
+		values[value.Name] = config
+	}
+	view := NewEnum(EnumConfig{
+		Name:        enum.Name(),
+		Description: enum.Description(),
+		Directives:  enum.directives,
+		Values:      values,
+	})
+	b.types[enum.Name()] = view
+	return view
+}
+
+func (b *schemaViewBuilder) newInputObject(inputObject *InputObject) *InputObject {
+	view := NewInputObject(InputObjectConfig{
+		Name:        inputObject.Name(),
+		Description: inputObject.Description(),
+		Directives:  inputObject.directives,
+		Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+			fields := InputObjectConfigFieldMap{}
+			for name, field := range inputObject.Fields() {
+				if b.visibility.InputField != nil && !b.visibility.InputField(inputObject, field) {
+					continue
+				}
+				ttype, ok := b.wrapped(field.Type).(Input)
+				if !ok {
+					continue
+				}
+				fields[name] = &InputObjectFieldConfig{
+					Type:         ttype,
+					DefaultValue: field.DefaultValue,
+					Description:  field.Description(),
+					Directives:   field.Directives,
+				}
+			}
+			return fields
+		}),
+	})
+	b.types[inputObject.Name()] = view
+	return view
+}
+
+// fields returns the visible fields of an object or interface.
+func (b *schemaViewBuilder) fields(parent Type, fieldMap FieldDefinitionMap) Fields {
+	fields := Fields{}
+	for name, fieldDef := range fieldMap {
+		if b.visibility.Field != nil && !b.visibility.Field(parent, fieldDef) {
+			continue
+		}
+		ttype, ok := b.wrapped(fieldDef.Type).(Output)
+		if !ok {
+			continue
+		}
+		args := FieldConfigArgument{}
+		for _, arg := range fieldDef.Args {
+			if b.visibility.Argument != nil && !b.visibility.Argument(fieldDef, arg) {
+				continue
+			}
+			argType, ok := b.wrapped(arg.Type).(Input)
+			if !ok {
+				continue
+			}
+			args[arg.Name()] = &ArgumentConfig{
+				Type:         argType,
+				DefaultValue: arg.DefaultValue,
+				Description:  arg.Description(),
+			}
+		}
+		fields[name] = &Field{
+			Name:              name,
+			Type:              ttype,
+			Args:              args,
+			Resolve:           fieldDef.Resolve,
+			Subscribe:         fieldDef.Subscribe,
+			DeprecationReason: fieldDef.DeprecationReason,
+			Description:       fieldDef.Description,
+			Directives:        fieldDef.Directives,
+			Cost:              fieldDef.Cost,
+			Authorize:         fieldDef.Authorize,
+		}
+	}
+	return fields
+}
+
+// resolveType returns a ResolveTypeFn resolving the types of the view from
+// resolveType, which resolves the types of the schema.
+func (b *schemaViewBuilder) resolveType(resolveType ResolveTypeFn) ResolveTypeFn {
+	if resolveType == nil {
+		return nil
+	}
+	return func(p ResolveTypeParams) *Object {
+		object := resolveType(p)
+		if object == nil {
+			return nil
+		}
+		view, _ := b.types[object.Name()].(*Object)
+		return view
+	}
+}
+
+// SchemaViews derives views of a schema with NewSchemaView and caches them
+// by visibility key, e.g. the kind of client of a request. It is safe for
+// concurrent use.
+type SchemaViews struct {
+	schema     Schema
+	visibility func(key string) Visibility
+
+	mu    sync.Mutex
+	views map[string]schemaViewResult
+}
+
+type schemaViewResult struct {
+	schema Schema
+	err    error
+}
+
+// NewSchemaViews returns the views of schema, where visibility returns the
+// Visibility of a key.
+func NewSchemaViews(schema Schema, visibility func(key string) Visibility) *SchemaViews {
+	return &SchemaViews{
+		schema:     schema,
+		visibility: visibility,
+		views:      map[string]schemaViewResult{},
+	}
+}
+
+// View returns the view of the schema for key, deriving it on first use.
+func (v *SchemaViews) View(key string) (Schema, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	view, ok := v.views[key]
+	if !ok {
+		view.schema, view.err = NewSchemaView(v.schema, v.visibility(key))
+		v.views[key] = view
+	}
+	return view.schema, view.err
+}
@@ -0,0 +1,164 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/testutil"
+)
+
+func internalTag() []*graphql.AppliedDirective {
+	return []*graphql.AppliedDirective{{
+		Name: graphql.TagDirectiveName,
+		Args: []*graphql.DirectiveArgument{{Name: "name", Value: "internal"}},
+	}}
+}
+
+var schemaViewStatusType = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Status",
+	Values: graphql.EnumValueConfigMap{
+		"ACTIVE":   &graphql.EnumValueConfig{Value: "active"},
+		"ARCHIVED": &graphql.EnumValueConfig{Value: "archived", Directives: internalTag()},
+	},
+})
+
+var schemaViewAuditType = graphql.NewObject(graphql.ObjectConfig{
+	Name:       "Audit",
+	Directives: internalTag(),
+	Fields: graphql.Fields{
+		"entries": &graphql.Field{Type: graphql.NewList(graphql.String)},
+	},
+})
+
+var schemaViewTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"status": &graphql.Field{
+				Type: schemaViewStatusType,
+				Args: graphql.FieldConfigArgument{
+					"debug": &graphql.ArgumentConfig{Type: graphql.Boolean},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return "active", nil
+				},
+			},
+			"statusCount": &graphql.Field{
+				Type:       graphql.Int,
+				Directives: internalTag(),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return 2, nil
+				},
+			},
+			"audit": &graphql.Field{
+				Type: schemaViewAuditType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return map[string]any{"entries": []string{"created"}}, nil
+				},
+			},
+		},
+	}),
+})
+
+func TestSchemaView_HidesElementsFromValidation(t *testing.T) {
+	view, err := graphql.NewSchemaView(schemaViewTestSchema, graphql.TagVisibility("partner"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        view,
+		RequestString: `{ statusCoun audit { entries } }`,
+	})
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			// statusCount is not suggested
+			testutil.RuleError(`Cannot query field "statusCoun" on type "Query". Did you mean "status"?`, 1, 3),
+			testutil.RuleError(`Cannot query field "audit" on type "Query".`, 1, 14),
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        view,
+		RequestString: `{ status }`,
+	})
+	expected = &graphql.Result{
+		Data: map[string]any{"status": "ACTIVE"},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSchemaView_HidesElementsFromIntrospection(t *testing.T) {
+	view, err := graphql.NewSchemaView(schemaViewTestSchema, graphql.Visibility{
+		Argument: func(field *graphql.FieldDefinition, arg *graphql.Argument) bool {
+			return arg.Name() != "debug"
+		},
+		EnumValue: func(enum *graphql.Enum, value *graphql.EnumValueDefinition) bool {
+			return value.Name != "ARCHIVED"
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema: view,
+		RequestString: `{
+			status: __type(name: "Status") { enumValues { name } }
+			query: __type(name: "Query") { fields { name args { name } } }
+		}`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	data := result.Data.(map[string]any)
+	expectedValues := []any{map[string]any{"name": "ACTIVE"}}
+	if values := data["status"].(map[string]any)["enumValues"]; !reflect.DeepEqual(expectedValues, values) {
+		t.Fatalf("Unexpected enum values, Diff: %v", testutil.Diff(expectedValues, values))
+	}
+	for _, field := range data["query"].(map[string]any)["fields"].([]any) {
+		field := field.(map[string]any)
+		if field["name"] == "status" && len(field["args"].([]any)) != 0 {
+			t.Fatalf("expected the debug argument to be hidden, got %v", field["args"])
+		}
+	}
+}
+
+func TestSchemaViews_CachesViewsByKey(t *testing.T) {
+	calls := map[string]int{}
+	views := graphql.NewSchemaViews(schemaViewTestSchema, func(key string) graphql.Visibility {
+		calls[key]++
+		return graphql.TagVisibility(key)
+	})
+	for i := 0; i < 2; i++ {
+		for _, key := range []string{"internal", "partner"} {
+			if _, err := views.View(key); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+	if expected := map[string]int{"internal": 1, "partner": 1}; !reflect.DeepEqual(expected, calls) {
+		t.Fatalf("Unexpected calls, Diff: %v", testutil.Diff(expected, calls))
+	}
+
+	view, _ := views.View("internal")
+	result := graphql.Do(graphql.Params{
+		Schema:        view,
+		RequestString: `{ statusCount audit { entries } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"statusCount": 2,
+			"audit":       map[string]any{"entries": []any{"created"}},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
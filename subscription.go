@@ -3,8 +3,10 @@ package graphql
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/ast"
 	"github.com/machship/graphql/language/parser"
 	"github.com/machship/graphql/language/source"
 )
@@ -23,43 +25,110 @@ type SubscribeParams struct {
 
 // Subscribe performs a subscribe operation on the given query and schema
 // To finish a subscription you can simply close the channel from inside the `Subscribe` function
-// currently does not support extensions hooks
+// The Init, ParseDidStart and ValidationDidStart hooks of the extensions run
+// once per subscription, and the other hooks once per event.
 func Subscribe(p Params) chan *Result {
+	cached, err := resolveTrustedDocument(&p)
+	if err != nil {
+		return sendOneResultAndClose(&Result{
+			Errors: []gqlerrors.FormattedError{formatRequestError(err)},
+		})
+	}
 
 	source := source.NewSource(&source.Source{
 		Body: []byte(p.RequestString),
 		Name: "GraphQL request",
 	})
 
-	// TODO run extensions hooks
+	// run init on the extensions
+	extErrs := handleExtensionsInits(&p)
+	if len(extErrs) != 0 {
+		return sendOneResultAndClose(&Result{
+			Errors: extErrs,
+		})
+	}
+
+	extErrs, parseFinishFn := handleExtensionsParseDidStart(&p)
+	if len(extErrs) != 0 {
+		return sendOneResultAndClose(&Result{
+			Errors: extErrs,
+		})
+	}
 
 	// parse the source
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	var AST *ast.Document
+	if cached != nil {
+		AST = cached.Document
+	} else {
+		AST, err = parser.Parse(parser.ParseParams{Source: source, Options: p.ParseOptions})
+	}
 	if err != nil {
+		// run parseFinishFuncs for extensions
+		extErrs = parseFinishFn(err)
 
 		// merge the errors from extensions and the original error from parser
+		extErrs = append(extErrs, gqlerrors.FormatErrors(err)...)
+		return sendOneResultAndClose(&Result{
+			Errors: extErrs,
+		})
+	}
+
+	// run parseFinish functions for extensions
+	extErrs = parseFinishFn(err)
+	if len(extErrs) != 0 {
+		return sendOneResultAndClose(&Result{
+			Errors: extErrs,
+		})
+	}
+
+	// notify extensions about the start of the validation
+	extErrs, validationFinishFn := handleExtensionsValidationDidStart(&p)
+	if len(extErrs) != 0 {
 		return sendOneResultAndClose(&Result{
-			Errors: gqlerrors.FormatErrors(err),
+			Errors: extErrs,
 		})
 	}
 
 	// validate document
-	validationResult := ValidateDocument(&p.Schema, AST, nil)
+	var validationResult ValidationResult
+	if cached != nil {
+		validationResult = cached.Validation
+	} else {
+		validationResult = ValidateDocument(&p.Schema, AST, nil)
+	}
+	validationResult = validateRequest(&p, AST, validationResult)
 
 	if !validationResult.IsValid {
 		// run validation finish functions for extensions
+		extErrs = validationFinishFn(validationResult.Errors)
+
+		// merge the errors from extensions and the original error from parser
+		extErrs = append(extErrs, validationResult.Errors...)
 		return sendOneResultAndClose(&Result{
-			Errors: validationResult.Errors,
+			Errors:     extErrs,
+			Extensions: validationResult.Extensions,
 		})
+	}
 
+	// run the validationFinishFuncs for extensions
+	extErrs = validationFinishFn(validationResult.Errors)
+	if len(extErrs) != 0 {
+		return sendOneResultAndClose(&Result{
+			Errors: extErrs,
+		})
 	}
+
 	return ExecuteSubscription(ExecuteParams{
-		Schema:        p.Schema,
-		Root:          p.RootObject,
-		AST:           AST,
-		OperationName: p.OperationName,
-		Args:          p.VariableValues,
-		Context:       p.Context,
+		Schema:              p.Schema,
+		Root:                p.RootObject,
+		AST:                 AST,
+		OperationName:       p.OperationName,
+		Args:                p.VariableValues,
+		Context:             p.Context,
+		ErrorPresenter:      p.ErrorPresenter,
+		Debug:               p.Debug,
+		OrderedResult:       p.OrderedResult,
+		IntrospectionPolicy: p.IntrospectionPolicy,
 	})
 }
 
@@ -71,7 +140,9 @@ func sendOneResultAndClose(res *Result) chan *Result {
 }
 
 // ExecuteSubscription is similar to graphql.Execute but returns a channel instead of a Result
-// currently does not support extensions
+// Each event is executed with graphql.Execute, which runs the ExecutionDidStart
+// and ResolveFieldDidStart hooks of the extensions and adds their results to
+// the Result of the event.
 func ExecuteSubscription(p ExecuteParams) chan *Result {
 
 	if p.Context == nil {
@@ -80,41 +151,45 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 
 	var mapSourceToResponse = func(payload any) *Result {
 		return Execute(ExecuteParams{
-			Schema:        p.Schema,
-			Root:          payload,
-			AST:           p.AST,
-			OperationName: p.OperationName,
-			Args:          p.Args,
-			Context:       p.Context,
+			Schema:              p.Schema,
+			Root:                payload,
+			AST:                 p.AST,
+			OperationName:       p.OperationName,
+			Args:                p.Args,
+			Context:             p.Context,
+			ErrorPresenter:      p.ErrorPresenter,
+			Debug:               p.Debug,
+			OrderedResult:       p.OrderedResult,
+			IntrospectionPolicy: p.IntrospectionPolicy,
 		})
 	}
 	var resultChannel = make(chan *Result)
 	go func() {
 		defer close(resultChannel)
+		var exeContext *executionContext
 		defer func() {
-			if err := recover(); err != nil {
-				e, ok := err.(error)
-				if !ok {
-					return
-				}
+			if r := recover(); r != nil {
 				resultChannel <- &Result{
-					Errors: gqlerrors.FormatErrors(e),
+					Errors: presentErrors(exeContext, recoveredError(r, nil, nil)),
 				}
 			}
 		}()
 
 		exeContext, err := buildExecutionContext(buildExecutionCtxParams{
-			Schema:        p.Schema,
-			Root:          p.Root,
-			AST:           p.AST,
-			OperationName: p.OperationName,
-			Args:          p.Args,
-			Context:       p.Context,
+			Schema:              p.Schema,
+			Root:                p.Root,
+			AST:                 p.AST,
+			OperationName:       p.OperationName,
+			Args:                p.Args,
+			Context:             p.Context,
+			ErrorPresenter:      p.ErrorPresenter,
+			Debug:               p.Debug,
+			IntrospectionPolicy: p.IntrospectionPolicy,
 		})
 
 		if err != nil {
 			resultChannel <- &Result{
-				Errors: gqlerrors.FormatErrors(err),
+				Errors: formatMultiError(err),
 			}
 
 			return
@@ -201,24 +276,31 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 			return
 		}
 
-		switch v := fieldResult.(type) {
-		case chan any:
-			for {
-				select {
-				case <-p.Context.Done():
-					return
-
-				case res, more := <-v:
-					if !more {
-						return
-					}
-					resultChannel <- mapSourceToResponse(res)
-				}
-			}
-		default:
+		stream, ok := newSourceStream(fieldResult)
+		if !ok {
 			resultChannel <- mapSourceToResponse(fieldResult)
 			return
 		}
+		defer stream.Close()
+		for {
+			event, err := stream.Next(p.Context)
+			if p.Context.Err() != nil || err == io.EOF {
+				return
+			}
+			var result *Result
+			if err != nil {
+				result = &Result{
+					Errors: presentErrors(exeContext, NewLocatedErrorWithPath(err, FieldASTsToNodeASTs(fieldNodes), fieldPath.AsArray())),
+				}
+			} else {
+				result = mapSourceToResponse(event)
+			}
+			select {
+			case <-p.Context.Done():
+				return
+			case resultChannel <- result:
+			}
+		}
 	}()
 
 	// return a result channel
@@ -0,0 +1,179 @@
+package graphql
+
+import (
+	"context"
+	"io"
+	"iter"
+	"reflect"
+	"sync"
+)
+
+// SourceStream is a stream of events which a Subscribe resolver may return.
+// ExecuteSubscription calls Next from a single goroutine, and Close once when
+// the stream ends or the context of the subscription is done.
+type SourceStream interface {
+	// Next returns the next event, or io.EOF at the end of the stream. Other
+	// errors are reported as results and the stream continues, so streams
+	// failing for good return io.EOF afterwards.
+	Next(ctx context.Context) (any, error)
+
+	// Close releases the resources of the stream.
+	Close()
+}
+
+// newSourceStream returns the stream of events of the value returned by a
+// Subscribe resolver, which is a SourceStream, a receive channel of any
+// element type, an iter.Seq of any element type or an iter.Seq2 of any
+// element type and error. It returns false for other values, which are a
+// single event.
+func newSourceStream(value any) (SourceStream, bool) {
+	if stream, ok := value.(SourceStream); ok {
+		return stream, true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Chan:
+		if v.Type().ChanDir()&reflect.RecvDir != 0 {
+			return &chanSourceStream{ch: v}, true
+		}
+	case reflect.Func:
+		if v.IsNil() {
+			return nil, false
+		}
+		yieldType, ok := iteratorYieldType(v.Type())
+		if !ok {
+			return nil, false
+		}
+		if yieldType.NumIn() == 1 {
+			seq := func(yield func(any) bool) {
+				v.Call([]reflect.Value{reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+					return []reflect.Value{reflect.ValueOf(yield(args[0].Interface()))}
+				})})
+			}
+			next, stop := iter.Pull(seq)
+			return newPullSourceStream(func() (any, error, bool) {
+				event, ok := next()
+				return event, nil, ok
+			}, stop), true
+		}
+		seq := func(yield func(any, error) bool) {
+			v.Call([]reflect.Value{reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+				err, _ := args[1].Interface().(error)
+				return []reflect.Value{reflect.ValueOf(yield(args[0].Interface(), err))}
+			})})
+		}
+		next, stop := iter.Pull2(seq)
+		return newPullSourceStream(next, stop), true
+	}
+	return nil, false
+}
+
+// iteratorYieldType returns the type of the yield function of an iter.Seq
+// or of an iter.Seq2 whose second value is an error.
+func iteratorYieldType(seqType reflect.Type) (reflect.Type, bool) {
+	if seqType.NumIn() != 1 || seqType.NumOut() != 0 {
+		return nil, false
+	}
+	yieldType := seqType.In(0)
+	if yieldType.Kind() != reflect.Func || yieldType.NumOut() != 1 || yieldType.Out(0).Kind() != reflect.Bool {
+		return nil, false
+	}
+	switch yieldType.NumIn() {
+	case 1:
+		return yieldType, true
+	case 2:
+		return yieldType, yieldType.In(1) == errorType
+	}
+	return nil, false
+}
+
+// chanSourceStream is the SourceStream of a channel, which is closed by its
+// sender.
+type chanSourceStream struct {
+	ch reflect.Value
+}
+
+func (s *chanSourceStream) Next(ctx context.Context) (any, error) {
+	chosen, event, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		{Dir: reflect.SelectRecv, Chan: s.ch},
+	})
+	if chosen == 0 {
+		return nil, ctx.Err()
+	}
+	if !ok {
+		return nil, io.EOF
+	}
+	return event.Interface(), nil
+}
+
+func (s *chanSourceStream) Close() {}
+
+// pullSourceStream is the SourceStream of an iterator. The iterator is
+// pulled by a goroutine of its own, so that Next returns when its context is
+// done even if the iterator is blocked. The iterator is stopped, i.e. its
+// yield returns false, once it yields again after the stream is closed.
+type pullSourceStream struct {
+	next func() (any, error, bool)
+	stop func()
+
+	// starts pull on the first call to Next
+	start  sync.Once
+	events chan pulledEvent
+	// closed by Close
+	done chan struct{}
+}
+
+type pulledEvent struct {
+	event any
+	err   error
+}
+
+func newPullSourceStream(next func() (any, error, bool), stop func()) *pullSourceStream {
+	return &pullSourceStream{
+		next:   next,
+		stop:   stop,
+		events: make(chan pulledEvent),
+		done:   make(chan struct{}),
+	}
+}
+
+// pull sends the events of the iterator until it ends or the stream is
+// closed. As the iterator must not be used from several goroutines at once,
+// pull alone calls next and stop.
+func (s *pullSourceStream) pull() {
+	defer close(s.events)
+	defer s.stop()
+	for {
+		event, err, ok := s.next()
+		if !ok {
+			return
+		}
+		select {
+		case s.events <- pulledEvent{event: event, err: err}:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *pullSourceStream) Next(ctx context.Context) (any, error) {
+	s.start.Do(func() {
+		go s.pull()
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case pulled, ok := <-s.events:
+		if !ok {
+			return nil, io.EOF
+		}
+		return pulled.event, pulled.err
+	}
+}
+
+func (s *pullSourceStream) Close() {
+	close(s.done)
+	// stop the iterator here if it was never pulled
+	s.start.Do(s.stop)
+}
@@ -1,11 +1,19 @@
 package graphql_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/location"
 	"github.com/machship/graphql/testutil"
 )
 
@@ -285,3 +293,325 @@ var dummyQuery = graphql.NewObject(graphql.ObjectConfig{
 		"hello": &graphql.Field{Type: graphql.String},
 	},
 })
+
+func TestSubscribe_RunsExtensionHooks(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	called := func(hook string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[hook]++
+	}
+	ext := newtestExt("tracing")
+	ext.initFn = func(ctx context.Context, p *graphql.Params) context.Context {
+		called("Init")
+		return ctx
+	}
+	ext.parseDidStartFn = func(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+		called("ParseDidStart")
+		return ctx, func(err error) {}
+	}
+	ext.validationDidStartFn = func(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+		called("ValidationDidStart")
+		return ctx, func([]gqlerrors.FormattedError) {}
+	}
+	ext.executionDidStartFn = func(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+		called("ExecutionDidStart")
+		return ctx, func(*graphql.Result) {}
+	}
+	ext.resolveFieldDidStartFn = func(ctx context.Context, i *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+		called("ResolveFieldDidStart")
+		return ctx, func(any, error) {}
+	}
+	ext.hasResultFn = func() bool {
+		return true
+	}
+	ext.getResultFn = func(context.Context) any {
+		return "traced"
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: dummyQuery,
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"events": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return p.Source, nil
+					},
+					Subscribe: makeSubscribeToStringFunction([]string{"a", "b"}),
+				},
+			},
+		}),
+		Extensions: []graphql.Extension{ext},
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+
+	results := []*graphql.Result{}
+	for result := range graphql.Subscribe(graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription { events }`,
+	}) {
+		results = append(results, result)
+	}
+
+	expected := []*graphql.Result{
+		{Data: map[string]any{"events": "a"}, Extensions: map[string]any{"tracing": "traced"}},
+		{Data: map[string]any{"events": "b"}, Extensions: map[string]any{"tracing": "traced"}},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %v results, got %v", len(expected), results)
+	}
+	for i := range expected {
+		if !testutil.EqualResults(expected[i], results[i]) {
+			t.Fatalf("Unexpected result %v, Diff: %v", i, testutil.Diff(expected[i], results[i]))
+		}
+	}
+	expectedCalls := map[string]int{
+		"Init":                 1,
+		"ParseDidStart":        1,
+		"ValidationDidStart":   1,
+		"ExecutionDidStart":    2,
+		"ResolveFieldDidStart": 2,
+	}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Fatalf("Unexpected calls, Diff: %v", testutil.Diff(expectedCalls, calls))
+	}
+}
+
+func subscribeEvents(t *testing.T, subscribe graphql.FieldResolveFn) []*graphql.Result {
+	schema := makeSubscriptionSchema(t, graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"events": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source, nil
+				},
+				Subscribe: subscribe,
+			},
+		},
+	})
+	results := []*graphql.Result{}
+	for result := range graphql.Subscribe(graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription { events }`,
+	}) {
+		results = append(results, result)
+	}
+	return results
+}
+
+type testSourceStream struct {
+	events []string
+	closed chan struct{}
+}
+
+func (s *testSourceStream) Next(ctx context.Context) (any, error) {
+	if len(s.events) == 0 {
+		// block until the subscription is cancelled
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	event := s.events[0]
+	s.events = s.events[1:]
+	return event, nil
+}
+
+func (s *testSourceStream) Close() {
+	close(s.closed)
+}
+
+func TestSubscribe_SupportsSourceStreams(t *testing.T) {
+	expected := []*graphql.Result{
+		{Data: map[string]any{"events": "a"}},
+		{Data: map[string]any{"events": "b"}},
+	}
+	tests := []struct {
+		name      string
+		subscribe graphql.FieldResolveFn
+	}{
+		{
+			name: "typed channel",
+			subscribe: func(p graphql.ResolveParams) (any, error) {
+				c := make(chan string, 2)
+				c <- "a"
+				c <- "b"
+				close(c)
+				return (<-chan string)(c), nil
+			},
+		},
+		{
+			name: "iter.Seq",
+			subscribe: func(p graphql.ResolveParams) (any, error) {
+				return iter.Seq[string](slices.Values([]string{"a", "b"})), nil
+			},
+		},
+		{
+			name: "iter.Seq2",
+			subscribe: func(p graphql.ResolveParams) (any, error) {
+				return iter.Seq2[string, error](func(yield func(string, error) bool) {
+					_ = yield("a", nil) && yield("b", nil)
+				}), nil
+			},
+		},
+	}
+	for _, test := range tests {
+		results := subscribeEvents(t, test.subscribe)
+		if len(results) != len(expected) {
+			t.Fatalf("%v: expected %v results, got %v", test.name, len(expected), results)
+		}
+		for i := range expected {
+			if !testutil.EqualResults(expected[i], results[i]) {
+				t.Fatalf("%v: unexpected result %v, Diff: %v", test.name, i, testutil.Diff(expected[i], results[i]))
+			}
+		}
+	}
+}
+
+func TestSubscribe_ReportsSourceErrors(t *testing.T) {
+	results := subscribeEvents(t, func(p graphql.ResolveParams) (any, error) {
+		return iter.Seq2[string, error](func(yield func(string, error) bool) {
+			_ = yield("a", nil) && yield("", errors.New("source failed")) && yield("b", nil)
+		}), nil
+	})
+	expected := []*graphql.Result{
+		{Data: map[string]any{"events": "a"}},
+		{Errors: []gqlerrors.FormattedError{{
+			Message:   "source failed",
+			Locations: []location.SourceLocation{{Line: 1, Column: 16}},
+			Path:      []any{"events"},
+		}}},
+		{Data: map[string]any{"events": "b"}},
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %v results, got %v", len(expected), results)
+	}
+	for i := range expected {
+		if !testutil.EqualResults(expected[i], results[i]) {
+			t.Fatalf("Unexpected result %v, Diff: %v", i, testutil.Diff(expected[i], results[i]))
+		}
+	}
+}
+
+func TestSubscribe_ClosesSourceStreamWhenCancelled(t *testing.T) {
+	stream := &testSourceStream{events: []string{"a"}, closed: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	schema := makeSubscriptionSchema(t, graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"events": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source, nil
+				},
+				Subscribe: func(p graphql.ResolveParams) (any, error) {
+					return stream, nil
+				},
+			},
+		},
+	})
+	results := graphql.Subscribe(graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription { events }`,
+		Context:       ctx,
+	})
+	expected := &graphql.Result{Data: map[string]any{"events": "a"}}
+	if result := <-results; !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	cancel()
+	select {
+	case <-stream.closed:
+	case <-time.After(time.Second):
+		t.Fatal("expected the source stream to be closed")
+	}
+	if _, more := <-results; more {
+		t.Fatal("expected the results to end")
+	}
+}
+
+func TestSubscribe_StopsIteratorWhenCancelledWhileBlocked(t *testing.T) {
+	release := make(chan struct{})
+	stopped := make(chan bool, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	schema := makeSubscriptionSchema(t, graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"events": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source, nil
+				},
+				Subscribe: func(p graphql.ResolveParams) (any, error) {
+					return iter.Seq[string](func(yield func(string) bool) {
+						if !yield("a") {
+							return
+						}
+						// block, ignoring the context, until released
+						<-release
+						stopped <- !yield("b")
+					}), nil
+				},
+			},
+		},
+	})
+	results := graphql.Subscribe(graphql.Params{
+		Schema:        schema,
+		RequestString: `subscription { events }`,
+		Context:       ctx,
+	})
+	expected := &graphql.Result{Data: map[string]any{"events": "a"}}
+	if result := <-results; !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	cancel()
+	select {
+	case _, more := <-results:
+		if more {
+			t.Fatal("expected the results to end")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the results to end while the iterator is blocked")
+	}
+	close(release)
+	select {
+	case ok := <-stopped:
+		if !ok {
+			t.Fatal("expected yield to return false once the subscription is cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the iterator to be stopped")
+	}
+}
+
+func TestSubscribe_RunsValidationRules(t *testing.T) {
+	schema := makeSubscriptionSchema(t, graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"events": &graphql.Field{
+				Type:      graphql.String,
+				Subscribe: makeSubscribeToStringFunction([]string{"a"}),
+			},
+		},
+	})
+	results := []*graphql.Result{}
+	for result := range graphql.Subscribe(graphql.Params{
+		Schema:          schema,
+		RequestString:   `subscription { events }`,
+		ValidationRules: []graphql.ValidationRuleFn{graphql.MaxRootFieldsRule(0)},
+	}) {
+		results = append(results, result)
+	}
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			testutil.RuleError(`Operation has 1 root fields, which exceeds the maximum of 0.`, 1, 1),
+		},
+	}
+	if len(results) != 1 || !testutil.EqualResults(expected, results[0]) {
+		t.Fatalf("Unexpected results: %v", results)
+	}
+}
@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/machship/graphql/language/parser"
+	"github.com/machship/graphql/language/source"
+)
+
+// TrustedDocumentsConfig configures a TrustedDocuments store.
+type TrustedDocumentsConfig struct {
+	// Schema every document is validated against.
+	Schema Schema
+	// Documents maps the IDs of the trusted documents to their text, e.g. as
+	// read by ParseTrustedDocumentsManifest.
+	Documents map[string]string
+	// Strict rejects requests which do not refer to a trusted document by
+	// its ID, so that only trusted documents are executed.
+	Strict bool
+}
+
+// TrustedDocuments is an allowlist of pre-registered documents which requests
+// refer to by ID with Params.DocumentID. The documents are parsed and
+// validated once, when the store is created.
+type TrustedDocuments struct {
+	schemaID  uint64
+	strict    bool
+	documents map[string]*trustedDocument
+}
+
+type trustedDocument struct {
+	body   string
+	parsed *CachedDocument
+}
+
+// ParseTrustedDocumentsManifest reads a manifest of trusted documents, a JSON
+// object mapping the ID of each document to its text.
+func ParseTrustedDocumentsManifest(r io.Reader) (map[string]string, error) {
+	documents := map[string]string{}
+	if err := json.NewDecoder(r).Decode(&documents); err != nil {
+		return nil, fmt.Errorf("invalid trusted documents manifest: %w", err)
+	}
+	return documents, nil
+}
+
+// NewTrustedDocuments parses and validates every document of config against
+// its schema. If any document is invalid, it returns a MultiError with an
+// error per invalid document, so that a broken manifest fails at startup.
+func NewTrustedDocuments(config TrustedDocumentsConfig) (*TrustedDocuments, error) {
+	ids := make([]string, 0, len(config.Documents))
+	for id := range config.Documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	td := &TrustedDocuments{
+		schemaID:  config.Schema.id,
+		strict:    config.Strict,
+		documents: make(map[string]*trustedDocument, len(config.Documents)),
+	}
+	errs := MultiError{}
+	for _, id := range ids {
+		body := config.Documents[id]
+		AST, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+			Body: []byte(body),
+			Name: "GraphQL request",
+		})})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("trusted document %q: %w", id, err))
+			continue
+		}
+		validationResult := ValidateDocument(&config.Schema, AST, nil)
+		for _, err := range validationResult.Errors {
+			errs = append(errs, fmt.Errorf("trusted document %q: %w", id, err))
+		}
+		td.documents[id] = &trustedDocument{
+			body:   body,
+			parsed: &CachedDocument{Document: AST, Validation: validationResult},
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return td, nil
+}
+
+// Get returns the text of the trusted document with the given ID.
+func (td *TrustedDocuments) Get(id string) (string, bool) {
+	document, ok := td.documents[id]
+	if !ok {
+		return "", false
+	}
+	return document.body, true
+}
+
+// Len returns the number of trusted documents.
+func (td *TrustedDocuments) Len() int {
+	return len(td.documents)
+}
+
+// Strict reports whether only trusted documents may be executed.
+func (td *TrustedDocuments) Strict() bool {
+	return td.strict
+}
+
+// resolveTrustedDocument fills in the request string of requests referring
+// to a trusted document, and rejects other requests in strict mode. It
+// returns the parsed and validated document if it is valid for the schema
+// of the request.
+func resolveTrustedDocument(p *Params) (*CachedDocument, error) {
+	if p.DocumentID == "" {
+		if p.TrustedDocuments != nil && p.TrustedDocuments.strict {
+			return nil, &RequestError{Message: "Only trusted documents may be executed, provide a document ID.", Code: "TRUSTED_DOCUMENT_REQUIRED"}
+		}
+		return nil, nil
+	}
+	if p.TrustedDocuments == nil {
+		return nil, &RequestError{Message: "Trusted documents are not supported.", Code: "TRUSTED_DOCUMENTS_NOT_SUPPORTED"}
+	}
+	document, ok := p.TrustedDocuments.documents[p.DocumentID]
+	if !ok {
+		return nil, &RequestError{Message: fmt.Sprintf("Unknown trusted document %q.", p.DocumentID), Code: "TRUSTED_DOCUMENT_NOT_FOUND"}
+	}
+	if p.RequestString != "" && p.RequestString != document.body {
+		return nil, &RequestError{Message: fmt.Sprintf("The request string does not match trusted document %q.", p.DocumentID), Code: "BAD_REQUEST"}
+	}
+	p.RequestString = document.body
+	if p.Schema.id != p.TrustedDocuments.schemaID {
+		// validated against another schema, validate it again
+		return nil, nil
+	}
+	return document.parsed, nil
+}
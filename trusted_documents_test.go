@@ -0,0 +1,169 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/testutil"
+	"github.com/machship/graphql/testutil/starwars"
+)
+
+const trustedDocumentsManifest = `{
+	"hero": "{ hero { name } }",
+	"typename": "{ __typename }"
+}`
+
+func newTestTrustedDocuments(t *testing.T, schema graphql.Schema, strict bool) *graphql.TrustedDocuments {
+	documents, err := graphql.ParseTrustedDocumentsManifest(strings.NewReader(trustedDocumentsManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	td, err := graphql.NewTrustedDocuments(graphql.TrustedDocumentsConfig{
+		Schema:    schema,
+		Documents: documents,
+		Strict:    strict,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return td
+}
+
+func TestDo_ExecutesTrustedDocumentByID(t *testing.T) {
+	schema := starwars.Schema
+	td := newTestTrustedDocuments(t, schema, true)
+	if td.Len() != 2 {
+		t.Fatalf("Unexpected number of documents: %v", td.Len())
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:           schema,
+		DocumentID:       "hero",
+		TrustedDocuments: td,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]any{
+		"hero": map[string]any{"name": "R2-D2"},
+	}
+	if !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestDo_TrustedDocumentsRejectsUnknownID(t *testing.T) {
+	schema := starwars.Schema
+	result := graphql.Do(graphql.Params{
+		Schema:           schema,
+		DocumentID:       "unknown",
+		TrustedDocuments: newTestTrustedDocuments(t, schema, false),
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != "TRUSTED_DOCUMENT_NOT_FOUND" {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestDo_TrustedDocumentsStrictModeRejectsRequestStrings(t *testing.T) {
+	schema := starwars.Schema
+	params := graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hero { name } }`,
+	}
+
+	params.TrustedDocuments = newTestTrustedDocuments(t, schema, true)
+	result := graphql.Do(params)
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != "TRUSTED_DOCUMENT_REQUIRED" {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if result.Data != nil {
+		t.Fatalf("Unexpected data: %v", result.Data)
+	}
+
+	params.TrustedDocuments = newTestTrustedDocuments(t, schema, false)
+	result = graphql.Do(params)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestSubscribe_TrustedDocumentsStrictModeRejectsRequestStrings(t *testing.T) {
+	schema := makeSubscriptionSchema(t, graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"events": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source, nil
+				},
+				Subscribe: makeSubscribeToStringFunction([]string{"a"}),
+			},
+		},
+	})
+	td, err := graphql.NewTrustedDocuments(graphql.TrustedDocumentsConfig{
+		Schema:    schema,
+		Documents: map[string]string{"events": "subscription { events }"},
+		Strict:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := []*graphql.Result{}
+	for result := range graphql.Subscribe(graphql.Params{
+		Schema:           schema,
+		RequestString:    `subscription { events }`,
+		TrustedDocuments: td,
+	}) {
+		results = append(results, result)
+	}
+	if len(results) != 1 || len(results[0].Errors) != 1 || results[0].Errors[0].Extensions["code"] != "TRUSTED_DOCUMENT_REQUIRED" {
+		t.Fatalf("Unexpected results: %v", results)
+	}
+
+	results = results[:0]
+	for result := range graphql.Subscribe(graphql.Params{
+		Schema:           schema,
+		DocumentID:       "events",
+		TrustedDocuments: td,
+	}) {
+		results = append(results, result)
+	}
+	expected := &graphql.Result{Data: map[string]any{"events": "a"}}
+	if len(results) != 1 || !testutil.EqualResults(expected, results[0]) {
+		t.Fatalf("Unexpected results: %v", results)
+	}
+}
+
+func TestNewTrustedDocuments_ValidatesEveryDocument(t *testing.T) {
+	_, err := graphql.NewTrustedDocuments(graphql.TrustedDocumentsConfig{
+		Schema: starwars.Schema,
+		Documents: map[string]string{
+			"valid":   "{ hero { name } }",
+			"invalid": "{ hero { email } }",
+			"broken":  "{ hero {",
+		},
+	})
+	var errs graphql.MultiError
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected a MultiError, got %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected an error per invalid document, got %v", errs)
+	}
+	if !strings.HasPrefix(errs[0].Error(), `trusted document "broken": `) {
+		t.Fatalf("Unexpected error: %v", errs[0])
+	}
+	if !strings.HasPrefix(errs[1].Error(), `trusted document "invalid": Cannot query field "email"`) {
+		t.Fatalf("Unexpected error: %v", errs[1])
+	}
+}
+
+func TestParseTrustedDocumentsManifest_RejectsInvalidJSON(t *testing.T) {
+	if _, err := graphql.ParseTrustedDocumentsManifest(strings.NewReader(`["{ a }"]`)); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
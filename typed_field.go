@@ -0,0 +1,233 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// NoArgs can be used as the Args type of NewField for fields without arguments.
+type NoArgs struct{}
+
+// TypedResolveFn is a type-safe resolve function receiving the parent value as
+// Source and the field arguments decoded into Args.
+type TypedResolveFn[Source, Args, Out any] func(ctx context.Context, source Source, args Args) (Out, error)
+
+// TypedThunkResolveFn is like TypedResolveFn, but returns a thunk which the
+// executor calls once the rest of the current level has been resolved.
+type TypedThunkResolveFn[Source, Args, Out any] func(ctx context.Context, source Source, args Args) (func() (Out, error), error)
+
+// NewField returns a Field of the given type whose Args are derived from the
+// fields of the Args struct and whose Resolve function calls resolve with the
+// parent value asserted to Source and the arguments decoded into Args.
+//
+// Each exported field of Args becomes an argument named after its `graphql`
+// or `json` tag, or its lower camel cased Go name. Strings, booleans, numbers,
+// time.Time and encoding.TextUnmarshaler implementations map to the built-in
+// scalars, slices to lists and named structs to input objects named after the
+// Go type. Non-pointer fields are non-null; pointer fields are nullable. The
+// returned Field may be adjusted before use, e.g. to replace an argument type
+// with an enum or to add a description.
+//
+// NewField panics if Args is not a struct or contains a field whose type
+// cannot be mapped to a GraphQL input type.
+//
+// If the parent value is not a Source, the field resolves to an error naming
+// both types rather than panicking.
+func NewField[Source, Args, Out any](ttype Output, resolve TypedResolveFn[Source, Args, Out]) *Field {
+	return &Field{
+		Type: ttype,
+		Args: mustArgsFromStruct(reflect.TypeOf((*Args)(nil)).Elem()),
+		Resolve: func(p ResolveParams) (any, error) {
+			source, args, err := typedResolveParams[Source, Args](p)
+			if err != nil {
+				return nil, err
+			}
+			return resolve(p.Context, source, args)
+		},
+	}
+}
+
+// NewThunkField is like NewField, but resolve returns a thunk which is called
+// by the executor after the sibling fields have been resolved, allowing
+// resolvers to batch their work, e.g. with a dataloader.
+func NewThunkField[Source, Args, Out any](ttype Output, resolve TypedThunkResolveFn[Source, Args, Out]) *Field {
+	return &Field{
+		Type: ttype,
+		Args: mustArgsFromStruct(reflect.TypeOf((*Args)(nil)).Elem()),
+		Resolve: func(p ResolveParams) (any, error) {
+			source, args, err := typedResolveParams[Source, Args](p)
+			if err != nil {
+				return nil, err
+			}
+			thunk, err := resolve(p.Context, source, args)
+			if err != nil || thunk == nil {
+				return nil, err
+			}
+			return func() (any, error) {
+				return thunk()
+			}, nil
+		},
+	}
+}
+
+// typedResolveParams asserts the source of p to Source and decodes its
+// arguments into Args.
+func typedResolveParams[Source, Args any](p ResolveParams) (source Source, args Args, err error) {
+	if p.Source != nil {
+		var ok bool
+		if source, ok = p.Source.(Source); !ok {
+			return source, args, fmt.Errorf("%v.%v expected source of type %v but got: %T.",
+				p.Info.ParentType, p.Info.FieldName, reflect.TypeOf((*Source)(nil)).Elem(), p.Source)
+		}
+	}
+	if err = p.DecodeArgs(&args); err != nil {
+		return source, args, err
+	}
+	return source, args, nil
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+
+	// cache of input objects derived from Go types, so that a type used by
+	// several fields maps to a single schema type
+	derivedInputObjects sync.Map // map[reflect.Type]*InputObject
+)
+
+func mustArgsFromStruct(t reflect.Type) FieldConfigArgument {
+	args, err := argsFromStruct(t)
+	if err != nil {
+		panic(err)
+	}
+	return args
+}
+
+// argsFromStruct derives a FieldConfigArgument from the fields of a struct.
+func argsFromStruct(t reflect.Type) (FieldConfigArgument, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("field arguments must be a struct, got %v", t)
+	}
+	args := FieldConfigArgument{}
+	for name, index := range decodableFields(t) {
+		field := t.FieldByIndex(index)
+		name = goFieldGraphQLName(field, name)
+		argType, err := inputTypeFromGo(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q of %v: %w", name, t, err)
+		}
+		args[name] = &ArgumentConfig{Type: argType}
+	}
+	return args, nil
+}
+
+// inputTypeFromGo maps a Go type to a GraphQL input type. Non-pointer types
+// are wrapped in NonNull.
+func inputTypeFromGo(t reflect.Type) (Input, error) {
+	if t.Kind() == reflect.Ptr {
+		return nullableInputTypeFromGo(t.Elem())
+	}
+	ttype, err := nullableInputTypeFromGo(t)
+	if err != nil {
+		return nil, err
+	}
+	return NewNonNull(ttype), nil
+}
+
+func nullableInputTypeFromGo(t reflect.Type) (Input, error) {
+	if t == timeType {
+		return DateTime, nil
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return String, nil
+	}
+	switch {
+	case t.Kind() == reflect.String:
+		return String, nil
+	case t.Kind() == reflect.Bool:
+		return Boolean, nil
+	case isIntKind(t.Kind()), isUintKind(t.Kind()):
+		return Int, nil
+	case isFloatKind(t.Kind()):
+		return Float, nil
+	case t.Kind() == reflect.Slice, t.Kind() == reflect.Array:
+		itemType, err := inputTypeFromGo(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return NewList(itemType), nil
+	case t.Kind() == reflect.Struct:
+		return inputObjectFromGo(t)
+	}
+	return nil, fmt.Errorf("cannot map %v to a GraphQL input type", t)
+}
+
+// inputObjectFromGo returns the input object derived from a named struct.
+func inputObjectFromGo(t reflect.Type) (*InputObject, error) {
+	if cached, ok := derivedInputObjects.Load(t); ok {
+		return cached.(*InputObject), nil
+	}
+	if t.Name() == "" {
+		return nil, fmt.Errorf("cannot map anonymous struct %v to a GraphQL input object", t)
+	}
+	// fields are resolved lazily to allow for recursive input types
+	inputObject := NewInputObject(InputObjectConfig{
+		Name: t.Name(),
+		Fields: InputObjectConfigFieldMapErrThunk(func() (InputObjectConfigFieldMap, error) {
+			return inputObjectFieldsFromGo(t)
+		}),
+	})
+	actual, _ := derivedInputObjects.LoadOrStore(t, inputObject)
+	// surface unsupported field types now rather than at schema creation
+	if _, err := inputObjectFieldsFromGo(t); err != nil {
+		derivedInputObjects.Delete(t)
+		return nil, err
+	}
+	return actual.(*InputObject), nil
+}
+
+func inputObjectFieldsFromGo(t reflect.Type) (InputObjectConfigFieldMap, error) {
+	fields := InputObjectConfigFieldMap{}
+	for name, index := range decodableFields(t) {
+		field := t.FieldByIndex(index)
+		name = goFieldGraphQLName(field, name)
+		fieldType, err := inputTypeFromGo(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q of %v: %w", name, t, err)
+		}
+		fields[name] = &InputObjectFieldConfig{Type: fieldType}
+	}
+	return fields, nil
+}
+
+// goFieldGraphQLName returns the GraphQL name of a struct field found by
+// decodableFields under name: its tag name if it has one, otherwise its Go
+// name in lower camel case.
+func goFieldGraphQLName(field reflect.StructField, name string) string {
+	if fieldTagName(field.Tag) == name {
+		return name
+	}
+	return lowerCamel(name)
+}
+
+// lowerCamel lower cases the leading initialism or first letter of a Go
+// identifier, e.g. ID to id, URLPath to urlPath and Name to name. The last
+// of several leading upper case letters starts the next word if a lower case
+// letter follows it.
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
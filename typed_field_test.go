@@ -0,0 +1,228 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/machship/graphql"
+	"github.com/machship/graphql/gqlerrors"
+	"github.com/machship/graphql/language/location"
+	"github.com/machship/graphql/testutil"
+)
+
+type typedFieldUser struct {
+	Name string
+}
+
+type typedFieldRange struct {
+	From int `json:"from"`
+	To   *int
+}
+
+type typedFieldGreetArgs struct {
+	Greeting string           `graphql:"greeting"`
+	Times    *int             `graphql:"times"`
+	Range    *typedFieldRange `graphql:"range"`
+}
+
+func TestNewField_DerivesArgumentsFromStruct(t *testing.T) {
+	field := graphql.NewField(graphql.String, func(ctx context.Context, user *typedFieldUser, args typedFieldGreetArgs) (string, error) {
+		return "", nil
+	})
+	if len(field.Args) != 3 {
+		t.Fatalf("expected 3 arguments, got %v", field.Args)
+	}
+	if got := field.Args["greeting"].Type.String(); got != "String!" {
+		t.Fatalf(`expected "greeting" to be String!, got %v`, got)
+	}
+	if got := field.Args["times"].Type.String(); got != "Int" {
+		t.Fatalf(`expected "times" to be Int, got %v`, got)
+	}
+	rangeType, ok := field.Args["range"].Type.(*graphql.InputObject)
+	if !ok {
+		t.Fatalf(`expected "range" to be an input object, got %v`, field.Args["range"].Type)
+	}
+	if rangeType.Name() != "typedFieldRange" {
+		t.Fatalf("expected input object to be named after the Go type, got %v", rangeType.Name())
+	}
+	fieldTypes := map[string]string{}
+	for name, field := range rangeType.Fields() {
+		fieldTypes[name] = field.Type.String()
+	}
+	expected := map[string]string{"from": "Int!", "to": "Int"}
+	if !reflect.DeepEqual(expected, fieldTypes) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, fieldTypes))
+	}
+
+	other := graphql.NewField(graphql.Int, func(ctx context.Context, user *typedFieldUser, args typedFieldGreetArgs) (int, error) {
+		return 0, nil
+	})
+	if other.Args["range"].Type != field.Args["range"].Type {
+		t.Fatalf("expected the same Go type to map to the same input object")
+	}
+}
+
+func TestNewField_PanicsOnUnsupportedArguments(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected NewField to panic")
+		}
+	}()
+	graphql.NewField(graphql.String, func(ctx context.Context, user *typedFieldUser, args struct{ C chan int }) (string, error) {
+		return "", nil
+	})
+}
+
+func TestNewField_ResolvesWithTypedSourceAndArgs(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewObject(graphql.ObjectConfig{
+			Name: "User",
+			Fields: graphql.Fields{
+				"name": graphql.NewField(graphql.String, func(ctx context.Context, user *typedFieldUser, args graphql.NoArgs) (string, error) {
+					return user.Name, nil
+				}),
+				"greet": graphql.NewField(graphql.String, func(ctx context.Context, user *typedFieldUser, args typedFieldGreetArgs) (string, error) {
+					greeting := args.Greeting + " " + user.Name
+					if args.Times != nil {
+						greeting += " x" + string(rune('0'+*args.Times))
+					}
+					if args.Range != nil && args.Range.To != nil {
+						greeting += " to " + string(rune('0'+*args.Range.To))
+					}
+					return greeting, nil
+				}),
+				"later": graphql.NewThunkField(graphql.String, func(ctx context.Context, user *typedFieldUser, args graphql.NoArgs) (func() (string, error), error) {
+					return func() (string, error) {
+						return "later " + user.Name, nil
+					}, nil
+				}),
+				"failing": graphql.NewThunkField(graphql.String, func(ctx context.Context, user *typedFieldUser, args graphql.NoArgs) (func() (string, error), error) {
+					return func() (string, error) {
+						return "", errors.New("thunk failed")
+					}, nil
+				}),
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return &typedFieldUser{Name: "Ada"}, nil
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			test {
+				name
+				greet(greeting: "Hello", times: 2, range: {from: 1, to: 3})
+				later
+				failing
+			}
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"test": map[string]any{
+				"name":    "Ada",
+				"greet":   "Hello Ada x2 to 3",
+				"later":   "later Ada",
+				"failing": nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "thunk failed",
+				Locations: []location.SourceLocation{{Line: 6, Column: 5}},
+				Path:      []any{"test", "failing"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestNewField_ReportsSourceTypeMismatch(t *testing.T) {
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewObject(graphql.ObjectConfig{
+			Name: "User",
+			Fields: graphql.Fields{
+				"name": graphql.NewField(graphql.String, func(ctx context.Context, user *typedFieldUser, args graphql.NoArgs) (string, error) {
+					return user.Name, nil
+				}),
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return map[string]any{"name": "Ada"}, nil
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test { name } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"test": map[string]any{
+				"name": nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "User.name expected source of type *graphql_test.typedFieldUser but got: map[string]interface {}.",
+				Locations: []location.SourceLocation{{Line: 1, Column: 10}},
+				Path:      []any{"test", "name"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+type typedFieldInitialismArgs struct {
+	ID      string
+	URL     string
+	URLPath string
+}
+
+func TestNewField_LowerCasesLeadingInitialisms(t *testing.T) {
+	field := graphql.NewField(graphql.String, func(ctx context.Context, user *typedFieldUser, args typedFieldInitialismArgs) (string, error) {
+		return args.ID + " " + args.URL + " " + args.URLPath, nil
+	})
+	names := []string{}
+	for name := range field.Args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	expectedNames := []string{"id", "url", "urlPath"}
+	if !reflect.DeepEqual(expectedNames, names) {
+		t.Fatalf("Unexpected argument names, Diff: %v", testutil.Diff(expectedNames, names))
+	}
+
+	schema := testSchema(t, &graphql.Field{
+		Type: graphql.NewObject(graphql.ObjectConfig{
+			Name: "User",
+			Fields: graphql.Fields{
+				"link": field,
+			},
+		}),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return &typedFieldUser{Name: "Ada"}, nil
+		},
+	})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ test { link(id: "1", url: "https://example.com", urlPath: "/users") } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"test": map[string]any{
+				"link": "1 https://example.com /users",
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -10,6 +10,10 @@ import (
 type ValidationResult struct {
 	IsValid bool
 	Errors  []gqlerrors.FormattedError
+	// Extensions reported by validation rules with
+	// ValidationContext.SetExtension, which Do adds to the extensions of the
+	// result.
+	Extensions map[string]any
 }
 
 /**
@@ -27,6 +31,12 @@ type ValidationResult struct {
  */
 
 func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRuleFn) (vr ValidationResult) {
+	return validateDocument(schema, astDoc, rules, nil)
+}
+
+// validateDocument validates a document for a request with the given
+// variable values, which rules may access with ValidationContext.VariableValues.
+func validateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRuleFn, variableValues map[string]any) (vr ValidationResult) {
 	if len(rules) == 0 {
 		rules = SpecifiedRules
 	}
@@ -43,7 +53,11 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 	typeInfo := NewTypeInfo(&TypeInfoConfig{
 		Schema: schema,
 	})
-	vr.Errors = VisitUsingRules(schema, typeInfo, astDoc, rules)
+	context := NewValidationContext(schema, astDoc, typeInfo)
+	context.variableValues = variableValues
+	visitUsingRules(context, typeInfo, astDoc, rules)
+	vr.Errors = context.Errors()
+	vr.Extensions = context.extensions
 	if len(vr.Errors) == 0 {
 		vr.IsValid = true
 	}
@@ -57,8 +71,12 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 // Had to expose it to unit test experimental customizable validation feature,
 // but not meant for public consumption
 func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) []gqlerrors.FormattedError {
-
 	context := NewValidationContext(schema, astDoc, typeInfo)
+	visitUsingRules(context, typeInfo, astDoc, rules)
+	return context.Errors()
+}
+
+func visitUsingRules(context *ValidationContext, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) {
 	visitors := []*visitor.VisitorOptions{}
 
 	for _, rule := range rules {
@@ -68,7 +86,6 @@ func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, r
 
 	// Visit the whole document with each instance of all provided rules.
 	visitor.Visit(astDoc, visitor.VisitWithTypeInfo(typeInfo, visitor.VisitInParallel(visitors...)), nil)
-	return context.Errors()
 }
 
 type HasSelectionSet interface {
@@ -95,6 +112,8 @@ type ValidationContext struct {
 	recursiveVariableUsages        map[*ast.OperationDefinition][]*VariableUsage
 	recursivelyReferencedFragments map[*ast.OperationDefinition][]*ast.FragmentDefinition
 	fragmentSpreads                map[*ast.SelectionSet][]*ast.FragmentSpread
+	variableValues                 map[string]any
+	extensions                     map[string]any
 }
 
 func NewValidationContext(schema *Schema, astDoc *ast.Document, typeInfo *TypeInfo) *ValidationContext {
@@ -118,6 +137,21 @@ func (ctx *ValidationContext) Errors() []gqlerrors.FormattedError {
 	return ctx.errors
 }
 
+// VariableValues returns the variable values of the request being validated,
+// if they are known, i.e. when validating Params.ValidationRules in Do.
+func (ctx *ValidationContext) VariableValues() map[string]any {
+	return ctx.variableValues
+}
+
+// SetExtension reports a value to add to the extensions of the result under
+// key, e.g. the computed cost of the operation.
+func (ctx *ValidationContext) SetExtension(key string, value any) {
+	if ctx.extensions == nil {
+		ctx.extensions = map[string]any{}
+	}
+	ctx.extensions[key] = value
+}
+
 func (ctx *ValidationContext) Schema() *Schema {
 	return ctx.schema
 }
@@ -285,3 +319,80 @@ func (ctx *ValidationContext) Directive() *Directive {
 func (ctx *ValidationContext) Argument() *Argument {
 	return ctx.typeInfo.Argument()
 }
+
+// selectionFold folds the fields of a selection set into a value, following
+// inline fragments and fragment spreads, e.g. to measure the depth or the
+// cost of an operation. A fragment is folded once and its value reused for
+// each spread. Fragments spread within themselves, which
+// NoFragmentCyclesRule reports, fold to the zero value there.
+type selectionFold[T any] struct {
+	context *ValidationContext
+	// field folds a field selected on parentType, which is nil if unknown.
+	field func(parentType Type, field *ast.Field) T
+	// combine combines the values of the selections of a selection set.
+	combine func(a, b T) T
+
+	// values of the fragments folded so far
+	fragments map[string]T
+	// fragments being folded, to break cycles
+	visiting map[string]bool
+}
+
+func newSelectionFold[T any](context *ValidationContext, field func(Type, *ast.Field) T, combine func(T, T) T) *selectionFold[T] {
+	return &selectionFold[T]{
+		context:   context,
+		field:     field,
+		combine:   combine,
+		fragments: map[string]T{},
+		visiting:  map[string]bool{},
+	}
+}
+
+func (f *selectionFold[T]) selectionSet(parentType Type, selectionSet *ast.SelectionSet) T {
+	var value T
+	if selectionSet == nil {
+		return value
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			value = f.combine(value, f.field(parentType, selection))
+		case *ast.InlineFragment:
+			fragmentType := parentType
+			if selection.TypeCondition != nil {
+				fragmentType = f.typeCondition(selection.TypeCondition)
+			}
+			value = f.combine(value, f.selectionSet(fragmentType, selection.SelectionSet))
+		case *ast.FragmentSpread:
+			value = f.combine(value, f.fragment(selection.Name.Value))
+		}
+	}
+	return value
+}
+
+func (f *selectionFold[T]) fragment(name string) T {
+	if value, ok := f.fragments[name]; ok {
+		return value
+	}
+	var value T
+	fragment := f.context.Fragment(name)
+	if fragment == nil || f.visiting[name] {
+		return value
+	}
+	f.visiting[name] = true
+	var fragmentType Type
+	if fragment.TypeCondition != nil {
+		fragmentType = f.typeCondition(fragment.TypeCondition)
+	}
+	value = f.selectionSet(fragmentType, fragment.SelectionSet)
+	delete(f.visiting, name)
+	f.fragments[name] = value
+	return value
+}
+
+func (f *selectionFold[T]) typeCondition(typeCondition *ast.Named) Type {
+	if typeCondition.Name == nil {
+		return nil
+	}
+	return f.context.Schema().Type(typeCondition.Name.Value)
+}
@@ -6,7 +6,6 @@ import (
 	"math"
 	"reflect"
 	"sort"
-	"strings"
 
 	"github.com/machship/graphql/gqlerrors"
 	"github.com/machship/graphql/language/ast"
@@ -16,28 +15,43 @@ import (
 
 // Prepares an object map of variableValues of the correct type based on the
 // provided variable definitions and arbitrary input. If the input cannot be
-// parsed to match the variable definitions, a GraphQLError will be returned.
+// parsed to match the variable definitions, a MultiError with an error per
+// invalid variable value will be returned.
 func getVariableValues(
 	schema Schema,
 	definitionASTs []*ast.VariableDefinition,
 	inputs map[string]any) (map[string]any, error) {
 	values := map[string]any{}
+	errs := MultiError{}
 	for _, defAST := range definitionASTs {
 		if defAST == nil || defAST.Variable == nil || defAST.Variable.Name == nil {
 			continue
 		}
 		varName := defAST.Variable.Name.Value
-		if varValue, err := getVariableValue(schema, defAST, inputs[varName]); err != nil {
-			return values, err
-		} else {
+		input, provided := inputs[varName]
+		varValue, err := getVariableValue(schema, defAST, input, provided)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// Variables that were neither provided nor defaulted are left out, so
+		// that arguments referencing them fall back to their own defaults.
+		if provided || defAST.DefaultValue != nil {
 			values[varName] = varValue
 		}
 	}
+	if len(errs) != 0 {
+		return values, errs
+	}
 	return values, nil
 }
 
 // Prepares an object map of argument values given a list of argument
 // definitions and list of argument AST nodes.
+//
+// An argument given an explicit null (either a null literal or a variable
+// provided as null) is present in the map with a nil value, while an omitted
+// argument is absent from the map unless it has a default value.
 func getArgumentValues(
 	argDefs []*Argument, argASTs []*ast.Argument,
 	variableValues map[string]any) map[string]any {
@@ -50,26 +64,46 @@ func getArgumentValues(
 	}
 	results := map[string]any{}
 	for _, argDef := range argDefs {
-		var (
-			tmp   any
-			value ast.Value
-		)
-		if tmpValue, ok := argASTMap[argDef.PrivateName]; ok {
-			value = tmpValue.Value
-		}
-		if tmp = valueFromAST(value, argDef.Type, variableValues); isNullish(tmp) {
-			tmp = argDef.DefaultValue
+		argAST, ok := argASTMap[argDef.PrivateName]
+		if !ok || argAST.Value == nil || isUnprovidedVariable(argAST.Value, variableValues) {
+			if !isNullish(argDef.DefaultValue) {
+				results[argDef.PrivateName] = argDef.DefaultValue
+			}
+			continue
 		}
-		if !isNullish(tmp) {
-			results[argDef.PrivateName] = tmp
+		value := valueFromAST(argAST.Value, argDef.Type, variableValues)
+		if isNullish(value) {
+			switch argAST.Value.(type) {
+			case *ast.NullValue, *ast.Variable:
+				// explicit null, preserved for the resolver
+			default:
+				// the literal could not be parsed, fall back to the default
+				if value = argDef.DefaultValue; isNullish(value) {
+					continue
+				}
+			}
 		}
+		results[argDef.PrivateName] = value
 	}
 	return results
 }
 
+// Returns true if valueAST is a variable which was not provided with the
+// request and has no default value.
+func isUnprovidedVariable(valueAST ast.Value, variables map[string]any) bool {
+	variable, ok := valueAST.(*ast.Variable)
+	if !ok || variable.Name == nil {
+		return false
+	}
+	_, provided := variables[variable.Name.Value]
+	return !provided
+}
+
 // Given a variable definition, and any value of input, return a value which
-// adheres to the variable definition, or throw an error.
-func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input any) (any, error) {
+// adheres to the variable definition, or throw an error. provided reports
+// whether the variable was present in the request at all, which distinguishes
+// an explicit null from an omitted variable.
+func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input any, provided bool) (any, error) {
 	ttype, err := typeFromAST(schema, definitionAST.Type)
 	if err != nil {
 		return nil, err
@@ -88,47 +122,72 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 		)
 	}
 
-	isValid, messages := isValidInputValue(input, ttype)
-	if isValid {
-		if isNullish(input) {
-			if definitionAST.DefaultValue != nil {
-				return valueFromAST(definitionAST.DefaultValue, ttype, nil), nil
-			}
-		}
-		return coerceValue(ttype, input), nil
+	if !provided && definitionAST.DefaultValue != nil {
+		return valueFromAST(definitionAST.DefaultValue, ttype, nil), nil
 	}
+	variablePath := "$" + variable.Name.Value
 	if isNullish(input) {
-		return "", gqlerrors.NewError(
-			fmt.Sprintf(`Variable "$%v" of required type `+
-				`"%v" was not provided.`, variable.Name.Value, printer.Print(definitionAST.Type)),
-			[]ast.Node{definitionAST},
-			"",
-			nil,
-			[]int{},
-			nil,
-		)
+		if _, ok := ttype.(*NonNull); ok {
+			return "", newVariableError(
+				fmt.Sprintf(`Variable "$%v" of required type `+
+					`"%v" was not provided.`, variable.Name.Value, printer.Print(definitionAST.Type)),
+				definitionAST,
+				variablePath,
+			)
+		}
+	}
+	problems := inputValueProblems(input, ttype, variablePath)
+	if len(problems) == 0 {
+		return coerceValue(ttype, input), nil
 	}
 	// convert input interface into string for error message
 	bts, _ := json.Marshal(input)
-	var (
-		inputStr = string(bts)
-		msg      string
-	)
-	if len(messages) > 0 {
-		msg = "\n" + strings.Join(messages, "\n")
+	errs := MultiError{}
+	for _, problem := range problems {
+		errs = append(errs, newVariableError(
+			fmt.Sprintf(`Variable "$%v" got invalid value %s.`+"\n%v", variable.Name.Value, bts, problem.message),
+			definitionAST,
+			problem.path,
+		))
+	}
+	if len(errs) == 1 {
+		return "", errs[0]
 	}
+	return "", errs
+}
 
-	return "", gqlerrors.NewError(
-		fmt.Sprintf(`Variable "$%v" got invalid value `+
-			`%v.%v`, variable.Name.Value, inputStr, msg),
+// newVariableError returns an error about the value of a variable at
+// inputPath, located at its definition and with the BAD_USER_INPUT code.
+func newVariableError(message string, definitionAST *ast.VariableDefinition, inputPath string) error {
+	return gqlerrors.NewError(
+		message,
 		[]ast.Node{definitionAST},
 		"",
 		nil,
 		[]int{},
-		nil,
+		&variableError{message: message, inputPath: inputPath},
 	)
 }
 
+// variableError is the original error of the errors about the values of
+// variables, exposing the path of the invalid input value, e.g.
+// `$input.items[3].weight`, in the "inputPath" extension.
+type variableError struct {
+	message   string
+	inputPath string
+}
+
+func (e *variableError) Error() string {
+	return e.message
+}
+
+func (e *variableError) Extensions() map[string]any {
+	return map[string]any{
+		"code":      "BAD_USER_INPUT",
+		"inputPath": e.inputPath,
+	}
+}
+
 // Given a type and any value, return a runtime value coerced to match the type.
 func coerceValue(ttype Input, value any) any {
 	if isNullish(value) {
@@ -156,13 +215,14 @@ func coerceValue(ttype Input, value any) any {
 		}
 
 		for name, field := range ttype.Fields() {
-			fieldValue := coerceValue(field.Type, valueMap[name])
-			if isNullish(fieldValue) {
-				fieldValue = field.DefaultValue
-			}
-			if !isNullish(fieldValue) {
-				obj[name] = fieldValue
+			fieldValue, ok := valueMap[name]
+			if !ok {
+				if !isNullish(field.DefaultValue) {
+					obj[name] = field.DefaultValue
+				}
+				continue
 			}
+			obj[name] = coerceValue(field.Type, fieldValue)
 		}
 		return obj
 	case *Scalar:
@@ -207,48 +267,63 @@ func typeFromAST(schema Schema, inputTypeAST ast.Type) (Type, error) {
 	}
 }
 
-// isValidInputValue alias isValidJSValue
-// Given a value and a GraphQL type, determine if the value will be
-// accepted for that type. This is primarily useful for validating the
-// runtime values of query variables.
-func isValidInputValue(value any, ttype Input) (bool, []string) {
+// inputValueProblem is a reason why an input value is invalid for its
+// type, at the given input path, e.g. `$input.items[3].weight`. The message
+// locates the value relative to the variable, e.g. `In field "items": In
+// element #3: In field "weight": ...`.
+type inputValueProblem struct {
+	path    string
+	message string
+}
+
+// withPrefix returns problems with their messages prefixed by prefix.
+func withPrefix(problems []inputValueProblem, prefix string) []inputValueProblem {
+	for i := range problems {
+		problems[i].message = prefix + problems[i].message
+	}
+	return problems
+}
+
+// inputValueProblems returns every reason why value, at the given input
+// path, is invalid for ttype.
+func inputValueProblems(value any, ttype Input, path string) []inputValueProblem {
 	if isNullish(value) {
 		if ttype, ok := ttype.(*NonNull); ok {
-			if ttype.OfType.Name() != "" {
-				return false, []string{fmt.Sprintf(`Expected "%v!", found null.`, ttype.OfType.Name())}
-			}
-			return false, []string{"Expected non-null value, found null."}
+			return []inputValueProblem{{
+				path:    path,
+				message: nullValueMessage(ttype),
+			}}
 		}
-		return true, nil
+		return nil
 	}
 	switch ttype := ttype.(type) {
 	case *NonNull:
-		return isValidInputValue(value, ttype.OfType)
+		return inputValueProblems(value, ttype.OfType, path)
 	case *List:
 		valType := reflect.ValueOf(value)
 		if valType.Kind() == reflect.Ptr {
 			valType = valType.Elem()
 		}
 		if valType.Kind() == reflect.Slice {
-			messagesReduce := []string{}
+			problems := []inputValueProblem{}
 			for i := 0; i < valType.Len(); i++ {
 				val := valType.Index(i).Interface()
-				_, messages := isValidInputValue(val, ttype.OfType)
-				for idx, message := range messages {
-					messagesReduce = append(messagesReduce, fmt.Sprintf(`In element #%v: %v`, idx+1, message))
-				}
+				elementProblems := inputValueProblems(val, ttype.OfType, fmt.Sprintf("%v[%v]", path, i))
+				problems = append(problems, withPrefix(elementProblems, fmt.Sprintf("In element #%v: ", i))...)
 			}
-			return (len(messagesReduce) == 0), messagesReduce
+			return problems
 		}
-		return isValidInputValue(value, ttype.OfType)
+		return inputValueProblems(value, ttype.OfType, path)
 
 	case *InputObject:
-		messagesReduce := []string{}
-
 		valueMap, ok := value.(map[string]any)
 		if !ok {
-			return false, []string{fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name())}
+			return []inputValueProblem{{
+				path:    path,
+				message: fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name()),
+			}}
 		}
+		problems := []inputValueProblem{}
 		fields := ttype.Fields()
 
 		// to ensure stable order of field evaluation
@@ -268,29 +343,53 @@ func isValidInputValue(value any, ttype Input) (bool, []string) {
 		// Ensure every provided field is defined.
 		for _, fieldName := range valueMapFieldNames {
 			if _, ok := fields[fieldName]; !ok {
-				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": Unknown field.`, fieldName))
+				problems = append(problems, inputValueProblem{
+					path:    path + "." + fieldName,
+					message: fmt.Sprintf(`In field "%v": Unknown field.`, fieldName),
+				})
 			}
 		}
 
 		// Ensure every defined field is valid.
 		for _, fieldName := range fieldNames {
-			_, messages := isValidInputValue(valueMap[fieldName], fields[fieldName].Type)
-			for _, message := range messages {
-				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": %v`, fieldName, message))
+			fieldType := fields[fieldName].Type
+			fieldValue, provided := valueMap[fieldName]
+			if fieldType, ok := fieldType.(*NonNull); ok && !provided && isNullish(fields[fieldName].DefaultValue) {
+				problems = append(problems, inputValueProblem{
+					path:    path + "." + fieldName,
+					message: fmt.Sprintf(`In field "%v": %v`, fieldName, nullValueMessage(fieldType)),
+				})
+				continue
 			}
+			fieldProblems := inputValueProblems(fieldValue, fieldType, path+"."+fieldName)
+			problems = append(problems, withPrefix(fieldProblems, fmt.Sprintf(`In field "%v": `, fieldName))...)
 		}
-		return (len(messagesReduce) == 0), messagesReduce
+		return problems
 	case *Scalar:
 		if parsedVal := ttype.ParseValue(value); isNullish(parsedVal) {
-			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
+			return []inputValueProblem{{
+				path:    path,
+				message: fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value),
+			}}
 		}
 	case *Enum:
 		if parsedVal := ttype.ParseValue(value); isNullish(parsedVal) {
-			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
+			return []inputValueProblem{{
+				path:    path,
+				message: fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value),
+			}}
 		}
 	}
 
-	return true, nil
+	return nil
+}
+
+// nullValueMessage describes a null value given for a non-null type.
+func nullValueMessage(ttype *NonNull) string {
+	if ttype.OfType.Name() != "" {
+		return fmt.Sprintf(`Expected "%v!", found null.`, ttype.OfType.Name())
+	}
+	return "Expected non-null value, found null."
 }
 
 // Returns true if a value is null, undefined, or NaN.
@@ -343,6 +442,7 @@ func isIterable(src any) bool {
  * | Input Object         | Object        |
  * | List                 | Array         |
  * | Boolean              | Boolean       |
+ * | Null                 | null          |
  * | String / Enum Value  | String        |
  * | Int / Float          | Number        |
  *
@@ -351,6 +451,9 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]any) any
 	if valueAST == nil {
 		return nil
 	}
+	if _, ok := valueAST.(*ast.NullValue); ok {
+		return nil
+	}
 	// precedence: value > type
 	if valueAST, ok := valueAST.(*ast.Variable); ok {
 		if valueAST.Name == nil || variables == nil {
@@ -391,15 +494,13 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]any) any
 		}
 		obj := map[string]any{}
 		for name, field := range ttype.Fields() {
-			var value any
-			if of, ok = fieldASTs[name]; ok {
-				value = valueFromAST(of.Value, field.Type, variables)
-			} else {
-				value = field.DefaultValue
-			}
-			if !isNullish(value) {
-				obj[name] = value
+			if of, ok = fieldASTs[name]; !ok || isUnprovidedVariable(of.Value, variables) {
+				if !isNullish(field.DefaultValue) {
+					obj[name] = field.DefaultValue
+				}
+				continue
 			}
+			obj[name] = valueFromAST(of.Value, field.Type, variables)
 		}
 		return obj
 	case *Scalar:
@@ -425,6 +425,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnNullForNestedNon
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input.c"},
 			},
 		},
 	}
@@ -456,6 +457,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnIncorrectType(t
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input"},
 			},
 		},
 	}
@@ -491,6 +493,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnOmissionOfNested
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input.c"},
 			},
 		},
 	}
@@ -521,13 +524,23 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnDeepNestedErrors
 		Errors: []gqlerrors.FormattedError{
 			{
 				Message: `Variable "$input" got invalid value {"na":{"a":"foo"}}.` +
-					"\nIn field \"na\": In field \"c\": Expected \"String!\", found null." +
+					"\nIn field \"na\": In field \"c\": Expected \"String!\", found null.",
+				Locations: []location.SourceLocation{
+					{
+						Line: 2, Column: 19,
+					},
+				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input.na.c"},
+			},
+			{
+				Message: `Variable "$input" got invalid value {"na":{"a":"foo"}}.` +
 					"\nIn field \"nb\": Expected \"String!\", found null.",
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 19,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input.nb"},
 			},
 		},
 	}
@@ -570,6 +583,7 @@ func TestVariables_ObjectsAndNullability_UsingVariables_ErrorsOnAdditionOfUnknow
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input.extra"},
 			},
 		},
 	}
@@ -680,7 +694,7 @@ func TestVariables_NullableScalars_AllowsNullableInputsToBeSetToNullInAVariable(
 	}
 	expected := &graphql.Result{
 		Data: map[string]any{
-			"fieldWithNullableStringInput": nil,
+			"fieldWithNullableStringInput": `null`,
 		},
 	}
 
@@ -777,6 +791,7 @@ func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeOmittedIn
 						Line: 2, Column: 31,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$value"},
 			},
 		},
 	}
@@ -813,6 +828,7 @@ func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeSetToNull
 						Line: 2, Column: 31,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$value"},
 			},
 		},
 	}
@@ -941,7 +957,7 @@ func TestVariables_ListsAndNullability_AllowsListsToBeNull(t *testing.T) {
 
 	expected := &graphql.Result{
 		Data: map[string]any{
-			"list": nil,
+			"list": `null`,
 		},
 	}
 	ast := testutil.TestParse(t, doc)
@@ -1038,6 +1054,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListsToBeNull(t *testi
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input"},
 			},
 		},
 	}
@@ -1124,7 +1141,7 @@ func TestVariables_ListsAndNullability_AllowsListsOfNonNullsToBeNull(t *testing.
 	}
 	expected := &graphql.Result{
 		Data: map[string]any{
-			"listNN": nil,
+			"listNN": `null`,
 		},
 	}
 	ast := testutil.TestParse(t, doc)
@@ -1194,6 +1211,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowListOfNonNullsToContainNull(t
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input[1]"},
 			},
 		},
 	}
@@ -1229,6 +1247,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToBeNull
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input"},
 			},
 		},
 	}
@@ -1296,6 +1315,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToContai
 						Line: 2, Column: 17,
 					},
 				},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input[1]"},
 			},
 		},
 	}
@@ -1466,3 +1486,246 @@ func TestVariables_UsesArgumentDefaultValues_WhenArgumentProvidedCannotBeParsed(
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestVariables_NullableScalars_AllowsNullableInputsToBeSetToNullDirectly(t *testing.T) {
+	doc := `
+      {
+        fieldWithNullableStringInput(input: null)
+      }
+	`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"fieldWithNullableStringInput": `null`,
+		},
+	}
+
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected.Data, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeSetToNullDirectly(t *testing.T) {
+	doc := `
+      {
+        fieldWithNonNullableStringInput(input: null)
+      }
+	`
+	expected := &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message: "Argument \"input\" has invalid value null.\nExpected \"String!\", found null.",
+				Locations: []location.SourceLocation{
+					{
+						Line: 3, Column: 48,
+					},
+				},
+			},
+		},
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        variablesTestSchema,
+		RequestString: doc,
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_DoesNotUseArgumentDefaultValues_WhenArgumentIsExplicitlyNull(t *testing.T) {
+	doc := `
+	{
+		fieldWithDefaultArgumentValue(input: null)
+	}
+	`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"fieldWithDefaultArgumentValue": `null`,
+		},
+	}
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected.Data, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_UsesArgumentDefaultValues_WhenVariableIsNotProvided(t *testing.T) {
+	doc := `
+	query optionalVariable($optional: String) {
+		fieldWithDefaultArgumentValue(input: $optional)
+	}
+	`
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"fieldWithDefaultArgumentValue": `"Hello World"`,
+		},
+	}
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected.Data, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_DoesNotUseArgumentDefaultValues_WhenVariableIsExplicitlyNull(t *testing.T) {
+	doc := `
+	query optionalVariable($optional: String) {
+		fieldWithDefaultArgumentValue(input: $optional)
+	}
+	`
+	params := map[string]any{
+		"optional": nil,
+	}
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"fieldWithDefaultArgumentValue": `null`,
+		},
+	}
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+		Args:   params,
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected.Data, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_ObjectsAndNullability_DistinguishesExplicitNullFromOmittedFields(t *testing.T) {
+	doc := `
+	query q($input: TestInputObject) {
+		literalNull: fieldWithObjectInput(input: {a: null, c: "foo"})
+		literalOmitted: fieldWithObjectInput(input: {c: "foo"})
+		variable: fieldWithObjectInput(input: $input)
+	}
+	`
+	params := map[string]any{
+		"input": map[string]any{
+			"b": nil,
+			"c": "foo",
+		},
+	}
+	expected := &graphql.Result{
+		Data: map[string]any{
+			"literalNull":    `{"a":null,"c":"foo"}`,
+			"literalOmitted": `{"c":"foo"}`,
+			"variable":       `{"b":null,"c":"foo"}`,
+		},
+	}
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+		Args:   params,
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected.Data, result.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_ReportsAllInvalidVariablesWithInputPaths(t *testing.T) {
+	itemType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ItemInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"weight": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+	orderType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "OrderInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"items": &graphql.InputObjectFieldConfig{Type: graphql.NewList(itemType)},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"order": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"input": &graphql.ArgumentConfig{Type: orderType},
+						"count": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	doc := `query ($input: OrderInput, $count: Int) { order(input: $input, count: $count) }`
+	params := map[string]any{
+		"input": map[string]any{
+			"items": []any{
+				map[string]any{"weight": 1.5},
+				map[string]any{"weight": 2},
+				map[string]any{"weight": 3},
+				map[string]any{"weight": "heavy"},
+			},
+		},
+		"count": "many",
+	}
+	expected := &graphql.Result{
+		Data: nil,
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message: `Variable "$input" got invalid value {"items":[{"weight":1.5},{"weight":2},{"weight":3},{"weight":"heavy"}]}.` +
+					"\nIn field \"items\": In element #3: In field \"weight\": Expected type \"Float\", found \"heavy\".",
+				Locations:  []location.SourceLocation{{Line: 1, Column: 8}},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$input.items[3].weight"},
+			},
+			{
+				Message: `Variable "$count" got invalid value "many".` +
+					"\nExpected type \"Int\", found \"many\".",
+				Locations:  []location.SourceLocation{{Line: 1, Column: 28}},
+				Extensions: map[string]any{"code": "BAD_USER_INPUT", "inputPath": "$count"},
+			},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, doc),
+		Args:   params,
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}